@@ -0,0 +1,157 @@
+package goresponse
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+
+	"github.com/labstack/echo/v4"
+)
+
+// unknownFieldPattern matches the errors of json.Decoder.DisallowUnknownFields
+var unknownFieldPattern = regexp.MustCompile(`^json: unknown field "(.*)"$`)
+
+type (
+	// JSONSerializer is an echo.JSONSerializer that decodes request bodies strictly and
+	// locates malformed JSON by line and column, see JSONBodyError
+	JSONSerializer struct {
+		// MaxBodyBytes limits request bodies, answered with a 413 when exceeded, no limit when 0
+		MaxBodyBytes int64
+		// DisallowUnknownFields rejects fields the target doesn't have
+		DisallowUnknownFields bool
+		echo.DefaultJSONSerializer
+	}
+	// JSONBodyError locates a decoding error in a JSON request body
+	JSONBodyError struct {
+		Err    error
+		Offset int64 // Byte offset of the error in the body
+		Line   int
+		Column int
+	}
+)
+
+// bodyErrorEntry is the response entry of a request body that couldn't be decoded
+type bodyErrorEntry struct {
+	def    *ErrorDefinition
+	field  string
+	render func(locale string) string
+}
+
+// Deserialize reads the request body into i
+func (s JSONSerializer) Deserialize(c echo.Context, i interface{}) error {
+	body := c.Request().Body
+	if s.MaxBodyBytes > 0 {
+		body = http.MaxBytesReader(c.Response(), body, s.MaxBodyBytes)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if s.DisallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(i); err != nil {
+		return newJSONBodyError(err, data, decoder.InputOffset())
+	}
+	return nil
+}
+
+func newJSONBodyError(err error, data []byte, inputOffset int64) *JSONBodyError {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	offset := inputOffset
+	switch {
+	case errors.As(err, &syntaxErr) && syntaxErr.Offset > 0:
+		// The offset counts the invalid character as read
+		offset = syntaxErr.Offset - 1
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		offset = int64(len(data))
+	}
+	line, column := lineColumn(data, offset)
+	return &JSONBodyError{Err: err, Offset: offset, Line: line, Column: column}
+}
+
+// Error implements the error interface
+func (e *JSONBodyError) Error() string {
+	return fmt.Sprintf("%v (line %d, column %d)", e.Err, e.Line, e.Column)
+}
+
+// Unwrap returns the decoding error
+func (e *JSONBodyError) Unwrap() error {
+	return e.Err
+}
+
+// lineColumn converts a byte offset of data to a 1-based line and column
+func lineColumn(data []byte, offset int64) (line, column int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	return bytes.Count(before, []byte("\n")) + 1, len(before) - bytes.LastIndexByte(before, '\n')
+}
+
+// classifyBodyError maps errors of binding a request, also when wrapped by Echo, to a
+// response entry
+func classifyBodyError(err error) (*bodyErrorEntry, bool) {
+	var (
+		maxBytesErr *http.MaxBytesError
+		syntaxErr   *json.SyntaxError
+		typeErr     *json.UnmarshalTypeError
+		bindingErr  *echo.BindingError
+	)
+
+	switch {
+	case errors.As(err, &maxBytesErr):
+		return newBodyErrorEntry(errDefBodyTooLarge, "body", "The request body is larger than %d bytes", maxBytesErr.Limit), true
+	case errors.As(err, &syntaxErr):
+		return newBodyErrorEntry(errDefMalformedJSON, "body", "Malformed JSON at %s: %s", bodyPosition(err, syntaxErr.Offset), syntaxErr.Error()), true
+	case errors.As(err, &typeErr):
+		return newBodyErrorEntry(errDefInvalidType, toSnakeCase(typeErr.Field), "Invalid value for %s. Expected %s", typeErr.Field, typeErr.Type.String()), true
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return newBodyErrorEntry(errDefIncompleteBody, "body", "The request body ended unexpectedly at %s", bodyPosition(err, -1)), true
+	case unknownField(err) != "":
+		field := unknownField(err)
+		return newBodyErrorEntry(errDefUnknownField, field, "%s isn't a known field", field), true
+	case errors.As(err, &bindingErr):
+		return newBodyErrorEntry(errDefInvalidParameter, bindingErr.Field, "Invalid value for %s: %v", bindingErr.Field, bindingErr.Message), true
+	case errors.Is(err, echo.ErrUnsupportedMediaType):
+		return &bodyErrorEntry{def: errDefUnsupportedMediaType, field: "body", render: errDefUnsupportedMediaType.localizedMessage}, true
+	default:
+		return nil, false
+	}
+}
+
+func newBodyErrorEntry(def *ErrorDefinition, field, format string, args ...interface{}) *bodyErrorEntry {
+	message := fmt.Sprintf(format, args...)
+	return &bodyErrorEntry{def: def, field: field, render: func(string) string { return message }}
+}
+
+// bodyPosition describes where a body error is, by line and column when the body was
+// read by JSONSerializer, by byte offset otherwise
+func bodyPosition(err error, offset int64) string {
+	var bodyErr *JSONBodyError
+	if errors.As(err, &bodyErr) {
+		return fmt.Sprintf("line %d, column %d", bodyErr.Line, bodyErr.Column)
+	}
+	if offset < 0 {
+		return "the end"
+	}
+	return fmt.Sprintf("offset %d", offset)
+}
+
+// unknownField returns the field of a DisallowUnknownFields error, "" for other errors
+func unknownField(err error) string {
+	found := findError(err, func(e error) bool { return unknownFieldPattern.MatchString(e.Error()) })
+	if found == nil {
+		return ""
+	}
+	return unknownFieldPattern.FindStringSubmatch(found.Error())[1]
+}
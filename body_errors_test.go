@@ -0,0 +1,168 @@
+package goresponse
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type bindUser struct {
+	Name string `json:"name" query:"name"`
+	Age  int    `json:"age" query:"age"`
+}
+
+// bindUserHandler binds the query with Echo's fluent binder, which reports the field, and
+// the body with the default binder
+func bindUserHandler(c echo.Context) error {
+	var user bindUser
+	if c.Request().Method == http.MethodGet {
+		return echo.QueryParamsBinder(c).Int("age", &user.Age).BindError()
+	}
+	return c.Bind(&user)
+}
+
+func TestBindErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		serializer  echo.JSONSerializer
+		method      string
+		target      string
+		contentType string
+		body        string
+		wantStatus  int
+		wantEntry   map[string]string
+	}{
+		{
+			name:        "syntax error by line and column",
+			serializer:  JSONSerializer{},
+			body:        "{\n  \"name\": \"Jane\",\n  \"age\": x\n}",
+			wantStatus:  http.StatusBadRequest,
+			wantEntry:   map[string]string{"field": "body", "code": ErrCodeMalformedJSON, "message": "Malformed JSON at line 3, column 10: invalid character 'x' looking for beginning of value"},
+			contentType: echo.MIMEApplicationJSON,
+		},
+		{
+			name:        "syntax error by offset with the default serializer",
+			serializer:  echo.DefaultJSONSerializer{},
+			body:        `{"name": }`,
+			wantStatus:  http.StatusBadRequest,
+			wantEntry:   map[string]string{"field": "body", "code": ErrCodeMalformedJSON, "message": "Malformed JSON at offset 10: invalid character '}' looking for beginning of value"},
+			contentType: echo.MIMEApplicationJSON,
+		},
+		{
+			name:        "type error",
+			serializer:  JSONSerializer{},
+			body:        `{"age": "ten"}`,
+			wantStatus:  http.StatusBadRequest,
+			wantEntry:   map[string]string{"field": "age", "code": ErrCodeInvalidType, "message": "Invalid value for age. Expected int"},
+			contentType: echo.MIMEApplicationJSON,
+		},
+		{
+			name:        "truncated body",
+			serializer:  JSONSerializer{},
+			body:        `{"name": "Ja`,
+			wantStatus:  http.StatusBadRequest,
+			wantEntry:   map[string]string{"field": "body", "code": ErrCodeIncompleteBody, "message": "The request body ended unexpectedly at line 1, column 13"},
+			contentType: echo.MIMEApplicationJSON,
+		},
+		{
+			name:        "unknown field",
+			serializer:  JSONSerializer{DisallowUnknownFields: true},
+			body:        `{"name": "Jane", "role": "admin"}`,
+			wantStatus:  http.StatusBadRequest,
+			wantEntry:   map[string]string{"field": "role", "code": ErrCodeUnknownField, "message": "role isn't a known field"},
+			contentType: echo.MIMEApplicationJSON,
+		},
+		{
+			name:        "body too large",
+			serializer:  JSONSerializer{MaxBodyBytes: 8},
+			body:        `{"name": "Jane"}`,
+			wantStatus:  http.StatusRequestEntityTooLarge,
+			wantEntry:   map[string]string{"field": "body", "code": ErrCodeBodyTooLarge, "message": "The request body is larger than 8 bytes"},
+			contentType: echo.MIMEApplicationJSON,
+		},
+		{
+			name:        "unsupported media type",
+			serializer:  JSONSerializer{},
+			body:        "name=Jane",
+			wantStatus:  http.StatusUnsupportedMediaType,
+			wantEntry:   map[string]string{"field": "body", "code": ErrCodeUnsupportedMediaType, "message": "The content type of the request body isn't supported"},
+			contentType: echo.MIMETextPlain,
+		},
+		{
+			name:       "query binding error",
+			serializer: JSONSerializer{},
+			method:     http.MethodGet,
+			target:     "/users?age=ten",
+			wantStatus: http.StatusBadRequest,
+			wantEntry:  map[string]string{"field": "age", "code": ErrCodeInvalidParameter, "message": "Invalid value for age: failed to bind field value to int"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.JSONSerializer = tt.serializer
+			e.HTTPErrorHandler = CustomErrorHandler
+			e.Any("/users", bindUserHandler)
+
+			method, target := tt.method, tt.target
+			if method == "" {
+				method, target = http.MethodPost, "/users"
+			}
+			req := httptest.NewRequest(method, target, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, tt.contentType)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			var body StandardErrorResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, []map[string]string{tt.wantEntry}, body.Errors)
+
+			// AddError maps the same errors
+			c := e.NewContext(httptest.NewRequest(method, target, strings.NewReader(tt.body)), httptest.NewRecorder())
+			c.Request().Header.Set(echo.HeaderContentType, tt.contentType)
+			response := NewStandardErrorResponse(http.StatusBadRequest).AddError(bindUserHandler(c))
+			assert.Equal(t, tt.wantStatus, response.Code)
+			assert.Equal(t, []map[string]string{tt.wantEntry}, response.Errors)
+		})
+	}
+}
+
+func TestAddEchoError(t *testing.T) {
+	response := NewStandardErrorResponse(http.StatusBadRequest).AddError(echo.ErrForbidden)
+	assert.Equal(t, http.StatusForbidden, response.Code)
+	assert.Equal(t, []map[string]string{{"field": "error", "code": "FORBIDDEN", "message": "Forbidden"}}, response.Errors)
+}
+
+func TestLineColumn(t *testing.T) {
+	tests := []struct {
+		offset     int64
+		wantLine   int
+		wantColumn int
+	}{
+		{offset: 0, wantLine: 1, wantColumn: 1},
+		{offset: 3, wantLine: 1, wantColumn: 4},
+		{offset: 4, wantLine: 2, wantColumn: 1},
+		{offset: 99, wantLine: 2, wantColumn: 3},
+	}
+
+	for _, tt := range tests {
+		line, column := lineColumn([]byte("abc\nde"), tt.offset)
+		assert.Equal(t, tt.wantLine, line)
+		assert.Equal(t, tt.wantColumn, column)
+	}
+}
+
+func TestJSONBodyErrorUnwrap(t *testing.T) {
+	err := newJSONBodyError(io.ErrUnexpectedEOF, []byte("{\n\"a\":"), 6)
+	assert.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+	assert.Equal(t, "unexpected EOF (line 2, column 5)", err.Error())
+}
@@ -0,0 +1,224 @@
+package goresponse
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Error codes of the errors reported by the package itself. Validation errors are
+// coded VALIDATION_<TAG>, e.g. VALIDATION_REQUIRED, and manual errors without a code
+// after their status, e.g. NOT_FOUND.
+const (
+	ErrCodeInvalidParameter     = "INVALID_PARAMETER"
+	ErrCodeInvalidType          = "INVALID_TYPE"
+	ErrCodeMalformedJSON        = "MALFORMED_JSON"
+	ErrCodeIncompleteBody       = "INCOMPLETE_BODY"
+	ErrCodeUnknownField         = "UNKNOWN_FIELD"
+	ErrCodeBodyTooLarge         = "BODY_TOO_LARGE"
+	ErrCodeUnsupportedMediaType = "UNSUPPORTED_MEDIA_TYPE"
+	ErrCodeNotFound             = "RESOURCE_NOT_FOUND"
+	ErrCodeConflict             = "CONFLICT"
+	ErrCodeUnauthorized         = "UNAUTHORIZED"
+	ErrCodeForbidden            = "FORBIDDEN"
+	ErrCodeValidation           = "VALIDATION_FAILED"
+	ErrCodeDuplicate            = "DUPLICATE_RESOURCE"
+	ErrCodeInvalidReference     = "INVALID_REFERENCE"
+	ErrCodeMissingData          = "MISSING_REQUIRED_DATA"
+	ErrCodeInvalidDataFormat    = "INVALID_DATA_FORMAT"
+	ErrCodeCheckViolation       = "CHECK_VIOLATION"
+	ErrCodeResourceInUse        = "RESOURCE_IN_USE"
+	ErrCodeConcurrentUpdate     = "CONCURRENT_UPDATE"
+	ErrCodeDatabaseTimeout      = "DATABASE_TIMEOUT"
+	ErrCodeDatabase             = "DATABASE_ERROR"
+	ErrCodeDatabaseUnavailable  = "DATABASE_UNAVAILABLE"
+	ErrCodeRequestCanceled      = "REQUEST_CANCELED"
+	ErrCodeRequestTimeout       = "REQUEST_TIMEOUT"
+	ErrCodeRateLimited          = "RATE_LIMITED"
+	ErrCodeServiceUnavailable   = "SERVICE_UNAVAILABLE"
+	ErrCodeInternal             = "INTERNAL_ERROR"
+)
+
+type (
+	// ErrorDefinition describes an error that clients can branch on by its stable Code
+	ErrorDefinition struct {
+		Code    string `json:"code"`
+		Status  int    `json:"status"`
+		Message string `json:"message"`
+		DocsURL string `json:"docs_url,omitempty"`
+	}
+	// ErrorCatalog is a registry of error definitions
+	ErrorCatalog struct {
+		mu          sync.RWMutex
+		definitions map[string]*ErrorDefinition
+	}
+)
+
+// DefaultErrorCatalog holds the definitions registered with RegisterError, including
+// the package's own errors
+var DefaultErrorCatalog = NewErrorCatalog()
+
+var (
+	errDefInvalidParameter     = RegisterError(ErrCodeInvalidParameter, http.StatusBadRequest, "A query parameter is invalid", "")
+	errDefInvalidType          = RegisterError(ErrCodeInvalidType, http.StatusBadRequest, "A value has the wrong type", "")
+	errDefMalformedJSON        = RegisterError(ErrCodeMalformedJSON, http.StatusBadRequest, "The request body isn't valid JSON", "")
+	errDefIncompleteBody       = RegisterError(ErrCodeIncompleteBody, http.StatusBadRequest, "The request body ended unexpectedly", "")
+	errDefUnknownField         = RegisterError(ErrCodeUnknownField, http.StatusBadRequest, "The request body has a field we don't know", "")
+	errDefBodyTooLarge         = RegisterError(ErrCodeBodyTooLarge, http.StatusRequestEntityTooLarge, "The request body is too large", "")
+	errDefUnsupportedMediaType = RegisterError(ErrCodeUnsupportedMediaType, http.StatusUnsupportedMediaType, "The content type of the request body isn't supported", "")
+	errDefNotFound             = RegisterError(ErrCodeNotFound, http.StatusNotFound, "We couldn't find what you're looking for", "")
+	errDefConflict             = RegisterError(ErrCodeConflict, http.StatusConflict, "This operation conflicts with an existing resource", "")
+	errDefUnauthorized         = RegisterError(ErrCodeUnauthorized, http.StatusUnauthorized, "Please authenticate to access this resource", "")
+	errDefForbidden            = RegisterError(ErrCodeForbidden, http.StatusForbidden, "You don't have permission to access this resource", "")
+	errDefValidation           = RegisterError(ErrCodeValidation, http.StatusUnprocessableEntity, "The submitted data failed validation", "")
+	errDefDuplicate            = RegisterError(ErrCodeDuplicate, http.StatusConflict, "This information already exists in our system", "")
+	errDefInvalidReference     = RegisterError(ErrCodeInvalidReference, http.StatusBadRequest, "This operation references invalid or non-existent data", "")
+	errDefMissingData          = RegisterError(ErrCodeMissingData, http.StatusBadRequest, "Required information is missing", "")
+	errDefInvalidFormat        = RegisterError(ErrCodeInvalidDataFormat, http.StatusBadRequest, "The provided data format is invalid", "")
+	errDefCheckViolation       = RegisterError(ErrCodeCheckViolation, http.StatusBadRequest, "The provided data breaks one of our data rules", "")
+	errDefResourceInUse        = RegisterError(ErrCodeResourceInUse, http.StatusConflict, "This data is still used by other data", "")
+	errDefConcurrentUpdate     = RegisterError(ErrCodeConcurrentUpdate, http.StatusConflict, "This data was changed by another request at the same time. Please try again", "")
+	errDefDatabaseTimeout      = RegisterError(ErrCodeDatabaseTimeout, http.StatusGatewayTimeout, "The database took too long to respond. Please try again", "")
+	errDefDatabase             = RegisterError(ErrCodeDatabase, http.StatusInternalServerError, "An unexpected database error occurred", "")
+	errDefDatabaseUnavailable  = RegisterError(ErrCodeDatabaseUnavailable, http.StatusInternalServerError, "We're having trouble connecting to our database. Please try again", "")
+	errDefRequestCanceled      = RegisterError(ErrCodeRequestCanceled, StatusClientClosedRequest, "The request was canceled before it completed", "")
+	errDefRequestTimeout       = RegisterError(ErrCodeRequestTimeout, http.StatusGatewayTimeout, "The request took too long to complete. Please try again", "")
+	errDefRateLimited          = RegisterError(ErrCodeRateLimited, http.StatusTooManyRequests, "You've exceeded the allowed number of requests. Please try again later", "")
+	errDefServiceUnavailable   = RegisterError(ErrCodeServiceUnavailable, http.StatusServiceUnavailable, "The service is temporarily unavailable. Please try again later", "")
+	errDefInternal             = RegisterError(ErrCodeInternal, http.StatusInternalServerError, "An unexpected error occurred", "")
+)
+
+// NewErrorCatalog creates an empty error catalog
+func NewErrorCatalog() *ErrorCatalog {
+	return &ErrorCatalog{definitions: make(map[string]*ErrorDefinition)}
+}
+
+// Register adds a definition to the catalog. Codes must be unique.
+func (c *ErrorCatalog) Register(def ErrorDefinition) (*ErrorDefinition, error) {
+	if def.Code == "" {
+		return nil, errors.New("error definition needs a code")
+	}
+	if statusText(def.Status) == "" {
+		return nil, fmt.Errorf("error definition %s has invalid status %d", def.Code, def.Status)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.definitions[def.Code]; exists {
+		return nil, fmt.Errorf("error code %s is already registered", def.Code)
+	}
+	c.definitions[def.Code] = &def
+	return &def, nil
+}
+
+// MustRegister is like Register but panics on invalid or duplicate definitions
+func (c *ErrorCatalog) MustRegister(def ErrorDefinition) *ErrorDefinition {
+	registered, err := c.Register(def)
+	if err != nil {
+		panic(err)
+	}
+	return registered
+}
+
+// Lookup returns the definition registered for code
+func (c *ErrorCatalog) Lookup(code string) (*ErrorDefinition, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	def, ok := c.definitions[code]
+	return def, ok
+}
+
+// Definitions returns every registered definition ordered by code
+func (c *ErrorCatalog) Definitions() []*ErrorDefinition {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	defs := make([]*ErrorDefinition, 0, len(c.definitions))
+	for _, def := range c.definitions {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool {
+		return defs[i].Code < defs[j].Code
+	})
+	return defs
+}
+
+// WriteJSON writes the catalog as a JSON array for API docs
+func (c *ErrorCatalog) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(c.Definitions())
+}
+
+// WriteMarkdown writes the catalog as a Markdown table for API docs
+func (c *ErrorCatalog) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("| Code | Status | Message | Docs |\n")
+	b.WriteString("| ---- | ------ | ------- | ---- |\n")
+	escape := strings.NewReplacer("|", "\\|", "\n", " ")
+	for _, def := range c.Definitions() {
+		docs := ""
+		if def.DocsURL != "" {
+			docs = fmt.Sprintf("[docs](%s)", def.DocsURL)
+		}
+		fmt.Fprintf(&b, "| `%s` | %d %s | %s | %s |\n", def.Code, def.Status, http.StatusText(def.Status), escape.Replace(def.Message), docs)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// RegisterError adds a definition to DefaultErrorCatalog, typically in a package level
+// var, and panics on invalid or duplicate codes
+func RegisterError(code string, status int, message, docsURL string) *ErrorDefinition {
+	return DefaultErrorCatalog.MustRegister(ErrorDefinition{Code: code, Status: status, Message: message, DocsURL: docsURL})
+}
+
+// New raises the error with its default message
+func (d *ErrorDefinition) New() *HTTPError {
+	return &HTTPError{Code: d.Status, Message: d.Message, ErrorCode: d.Code}
+}
+
+// Newf raises the error with a custom message
+func (d *ErrorDefinition) Newf(format string, args ...interface{}) *HTTPError {
+	return &HTTPError{Code: d.Status, Message: fmt.Sprintf(format, args...), ErrorCode: d.Code}
+}
+
+// Wrap raises the error with its default message, keeping err as the internal cause
+func (d *ErrorDefinition) Wrap(err error) *HTTPError {
+	return &HTTPError{Code: d.Status, Message: d.Message, ErrorCode: d.Code, Internal: err}
+}
+
+// HasErrorCode reports whether err is an *HTTPError with the given code
+func HasErrorCode(err error, code string) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.errorCode() == code
+}
+
+// errorCode returns the error's code, derived from its status when not set
+func (e *HTTPError) errorCode() string {
+	if e.ErrorCode != "" {
+		return e.ErrorCode
+	}
+	return statusErrorCode(e.Code)
+}
+
+// statusErrorCode derives a code from a status, e.g. UNPROCESSABLE_ENTITY for 422
+func statusErrorCode(status int) string {
+	text := statusText(status)
+	if text == "" {
+		return "ERROR"
+	}
+	text = strings.NewReplacer("'", "", "-", "_", " ", "_").Replace(text)
+	return strings.ToUpper(text)
+}
+
+// validationErrorCode codes a validation error after its tag, e.g. VALIDATION_REQUIRED
+func validationErrorCode(validationErr validator.FieldError) string {
+	return "VALIDATION_" + strings.ToUpper(validationErr.Tag())
+}
@@ -0,0 +1,141 @@
+package goresponse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+var errDefTestEmailTaken = RegisterError("TEST_EMAIL_TAKEN", http.StatusConflict, "This email is already registered", "https://docs.example.com/errors/email-taken")
+
+func TestErrorCatalog_Register(t *testing.T) {
+	catalog := NewErrorCatalog()
+
+	def, err := catalog.Register(ErrorDefinition{Code: "USER_EMAIL_TAKEN", Status: http.StatusConflict, Message: "Email taken"})
+	assert.NoError(t, err)
+	found, ok := catalog.Lookup("USER_EMAIL_TAKEN")
+	assert.True(t, ok)
+	assert.Same(t, def, found)
+
+	_, err = catalog.Register(ErrorDefinition{Code: "USER_EMAIL_TAKEN", Status: http.StatusConflict})
+	assert.EqualError(t, err, "error code USER_EMAIL_TAKEN is already registered")
+	_, err = catalog.Register(ErrorDefinition{Status: http.StatusConflict})
+	assert.EqualError(t, err, "error definition needs a code")
+	_, err = catalog.Register(ErrorDefinition{Code: "BROKEN", Status: 1000})
+	assert.EqualError(t, err, "error definition BROKEN has invalid status 1000")
+	assert.Panics(t, func() {
+		catalog.MustRegister(ErrorDefinition{Code: "USER_EMAIL_TAKEN", Status: http.StatusConflict})
+	})
+}
+
+func TestErrorCatalog_Generators(t *testing.T) {
+	catalog := NewErrorCatalog()
+	catalog.MustRegister(ErrorDefinition{Code: "ORDER_CLOSED", Status: http.StatusConflict, Message: "The order | is closed"})
+	catalog.MustRegister(ErrorDefinition{Code: "CART_EMPTY", Status: http.StatusUnprocessableEntity, Message: "The cart is empty", DocsURL: "https://docs.example.com/cart-empty"})
+
+	var markdown bytes.Buffer
+	assert.NoError(t, catalog.WriteMarkdown(&markdown))
+	assert.Equal(t, "| Code | Status | Message | Docs |\n"+
+		"| ---- | ------ | ------- | ---- |\n"+
+		"| `CART_EMPTY` | 422 Unprocessable Entity | The cart is empty | [docs](https://docs.example.com/cart-empty) |\n"+
+		"| `ORDER_CLOSED` | 409 Conflict | The order \\| is closed |  |\n", markdown.String())
+
+	var raw bytes.Buffer
+	assert.NoError(t, catalog.WriteJSON(&raw))
+	assert.JSONEq(t, `[
+		{"code": "CART_EMPTY", "status": 422, "message": "The cart is empty", "docs_url": "https://docs.example.com/cart-empty"},
+		{"code": "ORDER_CLOSED", "status": 409, "message": "The order | is closed"}
+	]`, raw.String())
+}
+
+func TestDefaultErrorCatalogHasBuiltinErrors(t *testing.T) {
+	for _, code := range []string{ErrCodeInvalidParameter, ErrCodeNotFound, ErrCodeDuplicate, ErrCodeInternal} {
+		_, ok := DefaultErrorCatalog.Lookup(code)
+		assert.True(t, ok, code)
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantField  string
+		wantCode   string
+		wantMsg    string
+	}{
+		{
+			name:       "catalog error",
+			err:        errDefTestEmailTaken.New(),
+			wantStatus: http.StatusConflict,
+			wantField:  "error",
+			wantCode:   "TEST_EMAIL_TAKEN",
+			wantMsg:    "This email is already registered",
+		},
+		{
+			name:       "catalog error with custom message",
+			err:        errDefTestEmailTaken.Newf("%s is already registered", "jane@example.com"),
+			wantStatus: http.StatusConflict,
+			wantField:  "error",
+			wantCode:   "TEST_EMAIL_TAKEN",
+			wantMsg:    "jane@example.com is already registered",
+		},
+		{
+			name:       "http error without code",
+			err:        &HTTPError{Code: http.StatusNotFound, Message: "user not found"},
+			wantStatus: http.StatusNotFound,
+			wantField:  "error",
+			wantCode:   "NOT_FOUND",
+			wantMsg:    "user not found",
+		},
+		{
+			name:       "validation error",
+			err:        validator.ValidationErrors{MockValidationError{FieldValue: "Email", TagValue: "required"}},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantField:  "email",
+			wantCode:   "VALIDATION_REQUIRED",
+			wantMsg:    "Please provide email",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := NewStandardErrorResponse(http.StatusBadRequest).AddError(tt.err)
+			assert.Equal(t, tt.wantStatus, response.Code)
+			assert.Equal(t, map[string]string{"field": tt.wantField, "code": tt.wantCode, "message": tt.wantMsg}, response.Errors[0])
+		})
+	}
+}
+
+func TestErrorDefinition_Wrap(t *testing.T) {
+	cause := fmt.Errorf("insert user: duplicate key")
+	err := fmt.Errorf("register: %w", errDefTestEmailTaken.Wrap(cause))
+
+	assert.True(t, HasErrorCode(err, "TEST_EMAIL_TAKEN"))
+	assert.False(t, HasErrorCode(err, "OTHER"))
+	assert.False(t, HasErrorCode(cause, "TEST_EMAIL_TAKEN"))
+}
+
+func TestCatalogErrorRendering(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodPost, "/users", nil), rec)
+
+	NewErrorHandler(ErrorHandlerConfig{Format: ErrorFormatProblem})(errDefTestEmailTaken.New(), c)
+
+	var problem map[string]interface{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, "https://docs.example.com/errors/email-taken", problem["type"])
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"field":   "error",
+		"code":    "TEST_EMAIL_TAKEN",
+		"message": "This email is already registered",
+	}}, problem["errors"])
+}
@@ -0,0 +1,391 @@
+// Package client consumes APIs that respond with goresponse envelopes
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tlabdotcom/goresponse"
+)
+
+// maxErrorBodySize limits how much of a non-JSON error body is kept in Error.Message
+const maxErrorBodySize = 512
+
+// DefaultMaxRetryDelay is the longest a Client waits before a retry, see WithMaxRetryDelay
+const DefaultMaxRetryDelay = time.Minute
+
+type (
+	// Client calls endpoints of a single API
+	Client struct {
+		baseURL    string
+		httpClient *http.Client
+		header     http.Header
+		maxRetries int
+		retryDelay time.Duration
+		maxDelay   time.Duration
+		retryAll   bool // Whether 503s are retried for methods that aren't idempotent
+		sleep      func(ctx context.Context, d time.Duration) error
+	}
+	// Option configures a Client
+	Option func(*Client)
+
+	// Page is a PaginatedResponse returned by the API, with Data decoded into T
+	Page[T any] struct {
+		*goresponse.PaginatedResponse
+		Data []T `json:"data"`
+	}
+
+	// Error is a StandardErrorResponse returned by the API
+	Error struct {
+		StatusCode int           // HTTP status of the response
+		RetryAfter time.Duration // When the request may be retried, from Retry-After or retry_after_seconds
+		goresponse.StandardErrorResponse
+	}
+
+	// problemBody holds the members of an application/problem+json error
+	problemBody struct {
+		Status            int                 `json:"status"`
+		Title             string              `json:"title"`
+		Detail            string              `json:"detail"`
+		Errors            []map[string]string `json:"errors"`
+		RequestID         string              `json:"request_id"`
+		ReferenceID       string              `json:"reference_id"`
+		RetryAfterSeconds int                 `json:"retry_after_seconds"`
+	}
+
+	singleEnvelope[T any] struct {
+		*goresponse.SingleDataResponse
+		Data T `json:"data"`
+	}
+)
+
+// WithHTTPClient sets the http.Client used for requests, http.DefaultClient by default
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithHeader adds a header to every request, e.g. Authorization
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.header.Add(key, value)
+	}
+}
+
+// WithRetry retries requests answered with a 429, or a 503 for idempotent methods, up to
+// maxRetries times. It waits as long as the API asks with Retry-After or
+// retry_after_seconds, otherwise it backs off exponentially from baseDelay.
+func WithRetry(maxRetries int, baseDelay time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.retryDelay = baseDelay
+	}
+}
+
+// WithMaxRetryDelay caps the wait before a retry, DefaultMaxRetryDelay by default. A
+// request the API asks to retry later than that isn't retried.
+func WithMaxRetryDelay(maxDelay time.Duration) Option {
+	return func(c *Client) {
+		c.maxDelay = maxDelay
+	}
+}
+
+// WithRetryNonIdempotent also retries POST and PATCH requests answered with a 503, for
+// APIs that don't process requests they answer with it
+func WithRetryNonIdempotent() Option {
+	return func(c *Client) {
+		c.retryAll = true
+	}
+}
+
+// New creates a Client for the API at baseURL
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		header:     http.Header{},
+		maxDelay:   DefaultMaxRetryDelay,
+		sleep:      sleep,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	details := make([]string, 0, len(e.Errors))
+	for _, fieldErr := range e.Errors {
+		details = append(details, fieldErr["field"]+": "+fieldErr["message"])
+	}
+	if len(details) == 0 {
+		return fmt.Sprintf("%d %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("%d %s (%s)", e.StatusCode, e.Message, strings.Join(details, "; "))
+}
+
+// FieldError returns the message reported for a field
+func (e *Error) FieldError(field string) (string, bool) {
+	for _, fieldErr := range e.Errors {
+		if fieldErr["field"] == field {
+			return fieldErr["message"], true
+		}
+	}
+	return "", false
+}
+
+// List fetches a single page of path, sending filter as query parameters
+func List[T any](ctx context.Context, c *Client, path string, filter *goresponse.FilterOptions) (*Page[T], error) {
+	var query url.Values
+	if filter != nil {
+		query = filter.URLValues()
+	}
+
+	page := &Page[T]{PaginatedResponse: &goresponse.PaginatedResponse{}}
+	if err := c.Do(ctx, http.MethodGet, path, query, nil, page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// All iterates over the items of every page of path, starting at filter
+func All[T any](ctx context.Context, c *Client, path string, filter *goresponse.FilterOptions, opts ...goresponse.PageOption) iter.Seq2[T, error] {
+	fetch := func(ctx context.Context, filter *goresponse.FilterOptions) (*goresponse.PaginatedResponse, error) {
+		page, err := List[T](ctx, c, path, filter)
+		if err != nil {
+			return nil, err
+		}
+		return page.response(), nil
+	}
+	return goresponse.AllPages[T](ctx, filter, fetch, opts...)
+}
+
+// MarshalJSON encodes the page like the PaginatedResponse it was decoded from
+func (p Page[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.response())
+}
+
+// response returns the PaginatedResponse of the page, carrying Data
+func (p *Page[T]) response() *goresponse.PaginatedResponse {
+	resp := goresponse.PaginatedResponse{}
+	if p.PaginatedResponse != nil {
+		resp = *p.PaginatedResponse
+	}
+	resp.Data = p.Data
+	return &resp
+}
+
+// Get fetches a SingleDataResponse from path and returns its data
+func Get[T any](ctx context.Context, c *Client, path string) (T, error) {
+	envelope := singleEnvelope[T]{SingleDataResponse: &goresponse.SingleDataResponse{}}
+	err := c.Do(ctx, http.MethodGet, path, nil, nil, &envelope)
+	return envelope.Data, err
+}
+
+// Do sends a request and decodes a successful JSON response into out. Error
+// responses are returned as *Error, throttled ones are retried when configured with
+// WithRetry.
+func (c *Client) Do(ctx context.Context, method, path string, query url.Values, body io.Reader, out interface{}) error {
+	// The body is buffered to be sent again on retries
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := c.do(ctx, method, path, query, payload, out)
+		var apiErr *Error
+		if !errors.As(err, &apiErr) || !c.retryable(method, apiErr) || attempt >= c.maxRetries {
+			return err
+		}
+		if err := c.sleep(ctx, c.backoff(apiErr, attempt)); err != nil {
+			return err
+		}
+	}
+}
+
+// do sends a single attempt of a request, without a body when payload is nil
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, payload []byte, out interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := c.newRequest(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return decodeError(resp)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding %s %s response: %w", method, req.URL.Path, err)
+	}
+	return nil
+}
+
+// retryable reports whether an error response of a method can be retried: throttled
+// requests weren't processed, unavailable ones only safely when the method is idempotent
+func (c *Client) retryable(method string, apiErr *Error) bool {
+	if apiErr.RetryAfter > c.maxDelay {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusServiceUnavailable:
+		return c.retryAll || idempotent(method)
+	default:
+		return false
+	}
+}
+
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns how long to wait before retrying after attempt, as long as the API
+// asked or exponentially longer each attempt, at most the maximum delay
+func (c *Client) backoff(apiErr *Error, attempt int) time.Duration {
+	if apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter
+	}
+	delay := c.retryDelay << attempt
+	if delay <= 0 || delay > c.maxDelay {
+		return c.maxDelay
+	}
+	return delay
+}
+
+// sleep waits for d, returning early with the error of ctx when it's done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (c *Client) newRequest(ctx context.Context, method, path string, query url.Values, body io.Reader) (*http.Request, error) {
+	u, err := url.Parse(c.baseURL + "/" + strings.TrimPrefix(path, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if len(query) > 0 {
+		merged := u.Query()
+		for key, values := range query {
+			merged[key] = values
+		}
+		u.RawQuery = merged.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range c.header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+// decodeError turns an error response into *Error, keeping as much of the body as possible
+func decodeError(resp *http.Response) error {
+	apiErr := &Error{StatusCode: resp.StatusCode}
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+
+	if jsonErr := json.Unmarshal(raw, &apiErr.StandardErrorResponse); jsonErr != nil || apiErr.Code == 0 {
+		apiErr.StandardErrorResponse = decodeProblem(raw, resp.StatusCode)
+	}
+	if apiErr.RequestID == "" {
+		apiErr.RequestID = resp.Header.Get("X-Request-ID")
+	}
+	apiErr.RetryAfter = parseRetryAfter(resp.Header.Get(goresponse.HeaderRetryAfter), time.Now())
+	if apiErr.RetryAfter == 0 {
+		apiErr.RetryAfter = time.Duration(apiErr.RetryAfterSeconds) * time.Second
+	}
+	return apiErr
+}
+
+// decodeProblem reads an application/problem+json body, or keeps the body as the message
+// when it isn't one
+func decodeProblem(raw []byte, statusCode int) goresponse.StandardErrorResponse {
+	var problem problemBody
+	if err := json.Unmarshal(raw, &problem); err != nil || problem.Status == 0 {
+		return goresponse.StandardErrorResponse{Code: statusCode, Message: errorBodyMessage(raw, statusCode)}
+	}
+	message := problem.Detail
+	if message == "" {
+		message = problem.Title
+	}
+	return goresponse.StandardErrorResponse{
+		Code:              problem.Status,
+		Message:           message,
+		Errors:            problem.Errors,
+		RequestID:         problem.RequestID,
+		ReferenceID:       problem.ReferenceID,
+		RetryAfterSeconds: problem.RetryAfterSeconds,
+	}
+}
+
+// parseRetryAfter reads a Retry-After header in seconds or as an HTTP date, 0 when
+// it's missing or invalid
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+	return 0
+}
+
+func errorBodyMessage(raw []byte, statusCode int) string {
+	message := strings.TrimSpace(string(raw))
+	if message == "" {
+		return http.StatusText(statusCode)
+	}
+	if len(message) > maxErrorBodySize {
+		message = message[:maxErrorBodySize]
+	}
+	return message
+}
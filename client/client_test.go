@@ -0,0 +1,320 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/tlabdotcom/goresponse"
+)
+
+type user struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func newTestServer(t *testing.T, users []user) *httptest.Server {
+	t.Helper()
+	e := echo.New()
+	e.GET("/users", func(c echo.Context) error {
+		if c.Request().Header.Get("Authorization") != "Bearer token" {
+			return goresponse.NewStandardErrorResponse(http.StatusUnauthorized).
+				AddMessageError("authorization", "missing token").JSON(c)
+		}
+		filter, err := goresponse.HandleFilterOptionsEcho(c)
+		if err != nil {
+			return goresponse.NewStandardErrorResponse(http.StatusBadRequest).AddError(err).JSON(c)
+		}
+		start := min(*filter.Offset, len(users))
+		end := min(start+filter.Limit, len(users))
+		return c.JSON(http.StatusOK, goresponse.GeneratePaginatedResponse(users[start:end], len(users), filter))
+	})
+	e.GET("/users/1", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, goresponse.GenerateSingleDataResponse(users[0], "", 0))
+	})
+	e.GET("/problem", func(c echo.Context) error {
+		return goresponse.NewStandardErrorResponse(http.StatusServiceUnavailable).
+			AddMessageError("database", "down for maintenance").
+			WithRetryAfter(30 * time.Second).ProblemJSON(c)
+	})
+	e.GET("/broken", func(c echo.Context) error {
+		return c.String(http.StatusBadGateway, "upstream unavailable")
+	})
+
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func testUsers() []user {
+	return []user{{1, "ann"}, {2, "bob"}, {3, "cid"}, {4, "dan"}, {5, "eve"}}
+}
+
+func TestList(t *testing.T) {
+	server := newTestServer(t, testUsers())
+	c := New(server.URL, WithHeader("Authorization", "Bearer token"))
+
+	filter := (&goresponse.FilterOptions{Page: 2, Limit: 2}).Validate()
+	page, err := List[user](context.Background(), c, "/users", filter)
+	assert.NoError(t, err)
+	assert.Equal(t, []user{{3, "cid"}, {4, "dan"}}, page.Data)
+	assert.Equal(t, 5, page.TotalData)
+	assert.Equal(t, 3, page.TotalPage)
+	assert.Equal(t, 2, page.CurrentPage)
+	assert.Equal(t, 2, page.Filters.Limit)
+
+	raw, err := json.Marshal(page)
+	assert.NoError(t, err)
+	var decoded Page[user]
+	assert.NoError(t, json.Unmarshal(raw, &decoded))
+	assert.Equal(t, page.Data, decoded.Data)
+	assert.Equal(t, page.TotalData, decoded.TotalData)
+}
+
+func TestAll(t *testing.T) {
+	users := testUsers()
+	server := newTestServer(t, users)
+	c := New(server.URL, WithHeader("Authorization", "Bearer token"))
+
+	var got []user
+	for item, err := range All[user](context.Background(), c, "/users", &goresponse.FilterOptions{Limit: 2}) {
+		assert.NoError(t, err)
+		got = append(got, item)
+	}
+	assert.Equal(t, users, got)
+
+	got = nil
+	for item, err := range All[user](context.Background(), c, "/users", nil) {
+		assert.NoError(t, err)
+		got = append(got, item)
+	}
+	assert.Equal(t, users, got, "A nil filter starts at the first page")
+}
+
+func TestGet(t *testing.T) {
+	server := newTestServer(t, testUsers())
+
+	got, err := Get[user](context.Background(), New(server.URL), "/users/1")
+	assert.NoError(t, err)
+	assert.Equal(t, user{1, "ann"}, got)
+}
+
+func TestErrors(t *testing.T) {
+	server := newTestServer(t, testUsers())
+
+	t.Run("standard error response", func(t *testing.T) {
+		req := New(server.URL, WithHeader("X-Request-ID", "req-1"))
+		_, err := List[user](context.Background(), req, "/users", nil)
+
+		var apiErr *Error
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+		assert.Equal(t, http.StatusUnauthorized, apiErr.Code)
+		assert.Equal(t, "req-1", apiErr.RequestID)
+		message, ok := apiErr.FieldError("authorization")
+		assert.True(t, ok)
+		assert.Equal(t, "missing token", message)
+		assert.Equal(t, "401 Please authenticate to access this resource (authorization: missing token)", err.Error())
+	})
+
+	t.Run("problem details", func(t *testing.T) {
+		_, err := Get[user](context.Background(), New(server.URL, WithHeader("X-Request-ID", "req-2")), "/problem")
+
+		var apiErr *Error
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusServiceUnavailable, apiErr.Code)
+		assert.Equal(t, "req-2", apiErr.RequestID)
+		assert.Equal(t, 30, apiErr.RetryAfterSeconds)
+		assert.Equal(t, 30*time.Second, apiErr.RetryAfter)
+		message, ok := apiErr.FieldError("database")
+		assert.True(t, ok)
+		assert.Equal(t, "down for maintenance", message)
+	})
+
+	t.Run("plain text body", func(t *testing.T) {
+		_, err := Get[user](context.Background(), New(server.URL), "/broken")
+
+		var apiErr *Error
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusBadGateway, apiErr.Code)
+		assert.Equal(t, "upstream unavailable", apiErr.Message)
+		assert.Empty(t, apiErr.Errors)
+	})
+}
+
+// newThrottledServer answers the first failures requests with failure, then echoes the body
+func newThrottledServer(t *testing.T, failures int, failure echo.HandlerFunc) (*httptest.Server, *[]string) {
+	t.Helper()
+	var bodies []string
+	e := echo.New()
+	e.HTTPErrorHandler = goresponse.CustomErrorHandler
+	e.Match([]string{http.MethodPost, http.MethodPut}, "/orders", func(c echo.Context) error {
+		raw, _ := io.ReadAll(c.Request().Body)
+		bodies = append(bodies, string(raw))
+		if len(bodies) <= failures {
+			return failure(c)
+		}
+		return c.JSON(http.StatusCreated, goresponse.GenerateSingleDataResponse(user{1, string(raw)}, "", http.StatusCreated))
+	})
+
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+	return server, &bodies
+}
+
+func TestRetry(t *testing.T) {
+	tooManyRequests := func(echo.Context) error {
+		return goresponse.TooManyRequests(goresponse.RateLimit{Limit: 10, Reset: 3 * time.Second})
+	}
+	tests := []struct {
+		name       string
+		method     string
+		opts       []Option
+		failures   int
+		failure    echo.HandlerFunc
+		maxRetries int
+		wantDelays []time.Duration
+		wantStatus int
+	}{
+		{
+			name:       "honours Retry-After",
+			failures:   2,
+			failure:    tooManyRequests,
+			maxRetries: 3,
+			wantDelays: []time.Duration{3 * time.Second, 3 * time.Second},
+		},
+		{
+			name:     "honours retry_after_seconds",
+			method:   http.MethodPut,
+			failures: 1,
+			failure: func(c echo.Context) error {
+				resp := goresponse.NewStandardErrorResponse(http.StatusServiceUnavailable).WithRetryAfter(7 * time.Second)
+				return c.JSON(resp.Code, resp)
+			},
+			maxRetries: 1,
+			wantDelays: []time.Duration{7 * time.Second},
+		},
+		{
+			name:       "backs off exponentially without a hint",
+			method:     http.MethodPut,
+			failures:   3,
+			failure:    func(echo.Context) error { return goresponse.Unavailable(0) },
+			maxRetries: 3,
+			wantDelays: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond},
+		},
+		{
+			name:       "caps the backoff",
+			method:     http.MethodPut,
+			opts:       []Option{WithMaxRetryDelay(150 * time.Millisecond)},
+			failures:   3,
+			failure:    func(echo.Context) error { return goresponse.Unavailable(0) },
+			maxRetries: 3,
+			wantDelays: []time.Duration{100 * time.Millisecond, 150 * time.Millisecond, 150 * time.Millisecond},
+		},
+		{
+			name:       "gives up when asked to wait longer than the maximum",
+			method:     http.MethodPut,
+			failures:   1,
+			failure:    func(echo.Context) error { return goresponse.Unavailable(24 * time.Hour) },
+			maxRetries: 3,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "unavailable isn't retried for POST",
+			failures:   1,
+			failure:    func(echo.Context) error { return goresponse.Unavailable(0) },
+			maxRetries: 3,
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "unavailable is retried for POST when opted in",
+			opts:       []Option{WithRetryNonIdempotent()},
+			failures:   1,
+			failure:    func(echo.Context) error { return goresponse.Unavailable(0) },
+			maxRetries: 3,
+			wantDelays: []time.Duration{100 * time.Millisecond},
+		},
+		{
+			name:       "gives up after the last retry",
+			failures:   3,
+			failure:    tooManyRequests,
+			maxRetries: 2,
+			wantDelays: []time.Duration{3 * time.Second, 3 * time.Second},
+			wantStatus: http.StatusTooManyRequests,
+		},
+		{
+			name:       "other errors aren't retried",
+			failures:   1,
+			failure:    func(echo.Context) error { return goresponse.Conflict("") },
+			maxRetries: 3,
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, bodies := newThrottledServer(t, tt.failures, tt.failure)
+			c := New(server.URL, append([]Option{WithRetry(tt.maxRetries, 100*time.Millisecond)}, tt.opts...)...)
+			var delays []time.Duration
+			c.sleep = func(_ context.Context, d time.Duration) error {
+				delays = append(delays, d)
+				return nil
+			}
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			var out map[string]interface{}
+			err := c.Do(context.Background(), method, "/orders", nil, strings.NewReader("ann"), &out)
+			assert.Equal(t, tt.wantDelays, delays)
+			for _, body := range *bodies {
+				assert.Equal(t, "ann", body)
+			}
+			if tt.wantStatus == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var apiErr *Error
+			assert.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, tt.wantStatus, apiErr.StatusCode)
+		})
+	}
+}
+
+func TestRetryCanceled(t *testing.T) {
+	server, bodies := newThrottledServer(t, 1, func(echo.Context) error { return goresponse.Unavailable(time.Hour) })
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := New(server.URL, WithRetry(1, time.Second), WithMaxRetryDelay(2*time.Hour)).Do(ctx, http.MethodPut, "/orders", nil, nil, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Len(t, *bodies, 1)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "120", want: 2 * time.Minute},
+		{value: "Wed, 01 May 2024 12:00:30 GMT", want: 30 * time.Second},
+		{value: "Wed, 01 May 2024 11:00:00 GMT", want: 0},
+		{value: "-5", want: 0},
+		{value: "soon", want: 0},
+		{value: "", want: 0},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, parseRetryAfter(tt.value, now), tt.value)
+	}
+}
@@ -0,0 +1,74 @@
+package goresponse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// StatusClientClosedRequest is the non-standard status of requests the client
+// abandoned before the response was ready
+const StatusClientClosedRequest = 499
+
+// ErrorCategoryKey is the echo context key the error handler stores the ErrorCategory
+// of the handled error under, for logging and metrics middlewares
+const ErrorCategoryKey = "error_category"
+
+// ErrorCategory groups errors for logging and metrics, so canceled and timed out
+// requests don't count as server failures
+type ErrorCategory string
+
+const (
+	ErrorCategoryClient   ErrorCategory = "client"
+	ErrorCategoryServer   ErrorCategory = "server"
+	ErrorCategoryCanceled ErrorCategory = "canceled"
+	ErrorCategoryTimeout  ErrorCategory = "timeout"
+)
+
+// ErrorCategoryForStatus returns the category of an error response status
+func ErrorCategoryForStatus(status int) ErrorCategory {
+	switch {
+	case status == StatusClientClosedRequest:
+		return ErrorCategoryCanceled
+	case status == http.StatusRequestTimeout, status == http.StatusGatewayTimeout:
+		return ErrorCategoryTimeout
+	case status >= http.StatusInternalServerError:
+		return ErrorCategoryServer
+	default:
+		return ErrorCategoryClient
+	}
+}
+
+// Category returns the category of the response's status
+func (ser *StandardErrorResponse) Category() ErrorCategory {
+	return ErrorCategoryForStatus(ser.Code)
+}
+
+// ClassifyContextError maps context.Canceled to a 499 and context.DeadlineExceeded to
+// a 504, also when wrapped, e.g. by a database driver
+func ClassifyContextError(err error) (*ErrorDefinition, bool) {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return errDefRequestCanceled, true
+	case errors.Is(err, context.DeadlineExceeded):
+		return errDefRequestTimeout, true
+	default:
+		return nil, false
+	}
+}
+
+// statusText is http.StatusText, which also knows StatusClientClosedRequest
+func statusText(status int) string {
+	if status == StatusClientClosedRequest {
+		return "Client Closed Request"
+	}
+	return http.StatusText(status)
+}
+
+// clientGone reports whether the client closed the connection, so there's no one to
+// send the response to
+func clientGone(c echo.Context) bool {
+	return errors.Is(c.Request().Context().Err(), context.Canceled)
+}
@@ -0,0 +1,94 @@
+package goresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantStatus   int
+		wantCode     string
+		wantCategory ErrorCategory
+	}{
+		{
+			name:         "canceled",
+			err:          context.Canceled,
+			wantStatus:   StatusClientClosedRequest,
+			wantCode:     ErrCodeRequestCanceled,
+			wantCategory: ErrorCategoryCanceled,
+		},
+		{
+			name:         "deadline wrapped by a driver",
+			err:          fmt.Errorf("query users: %w", context.DeadlineExceeded),
+			wantStatus:   http.StatusGatewayTimeout,
+			wantCode:     ErrCodeRequestTimeout,
+			wantCategory: ErrorCategoryTimeout,
+		},
+		{
+			name:         "unknown error",
+			err:          errors.New("cache unavailable"),
+			wantStatus:   http.StatusInternalServerError,
+			wantCode:     ErrCodeInternal,
+			wantCategory: ErrorCategoryServer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := NewStandardErrorResponse(http.StatusBadRequest).AddError(tt.err)
+			assert.Equal(t, tt.wantStatus, response.Code)
+			assert.Equal(t, tt.wantCode, response.Errors[0]["code"])
+			assert.Equal(t, tt.wantCategory, response.Category())
+
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+			CustomErrorHandler(tt.err, c)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantCategory, c.Get(ErrorCategoryKey))
+		})
+	}
+}
+
+func TestErrorHandlerSkipsGoneClients(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx), rec)
+
+	CustomErrorHandler(fmt.Errorf("load orders: %w", ctx.Err()), c)
+
+	assert.False(t, c.Response().Committed)
+	assert.Empty(t, rec.Body.String())
+	assert.Equal(t, ErrorCategoryCanceled, c.Get(ErrorCategoryKey))
+}
+
+func TestErrorCategoryForStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		want   ErrorCategory
+	}{
+		{status: http.StatusNotFound, want: ErrorCategoryClient},
+		{status: http.StatusRequestTimeout, want: ErrorCategoryTimeout},
+		{status: StatusClientClosedRequest, want: ErrorCategoryCanceled},
+		{status: http.StatusServiceUnavailable, want: ErrorCategoryServer},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			assert.Equal(t, tt.want, ErrorCategoryForStatus(tt.status))
+		})
+	}
+}
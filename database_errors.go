@@ -0,0 +1,301 @@
+package goresponse
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+type (
+	// DatabaseError is a database error classified by a DatabaseErrorClassifier
+	DatabaseError struct {
+		Definition *ErrorDefinition
+		Constraint string // Violated constraint, e.g. users_email_key
+		Column     string // Offending column, e.g. email
+		Table      string
+	}
+	// DatabaseErrorClassifier recognizes the errors of a database driver
+	DatabaseErrorClassifier func(err error) (*DatabaseError, bool)
+)
+
+// databaseClassifiers holds the classifiers registered with RegisterDatabaseErrorClassifier
+var databaseClassifiers struct {
+	sync.RWMutex
+	registered []DatabaseErrorClassifier
+}
+
+var (
+	// postgresCodes maps SQLSTATE codes to error definitions
+	postgresCodes = map[string]*ErrorDefinition{
+		"23505": errDefDuplicate,           // unique_violation
+		"23503": errDefInvalidReference,    // foreign_key_violation
+		"23502": errDefMissingData,         // not_null_violation
+		"23514": errDefCheckViolation,      // check_violation
+		"23P01": errDefDuplicate,           // exclusion_violation
+		"40001": errDefConcurrentUpdate,    // serialization_failure
+		"40P01": errDefConcurrentUpdate,    // deadlock_detected
+		"55P03": errDefConcurrentUpdate,    // lock_not_available
+		"57014": errDefDatabaseTimeout,     // query_canceled
+		"53300": errDefDatabaseUnavailable, // too_many_connections
+		"57P01": errDefDatabaseUnavailable, // admin_shutdown
+	}
+	// postgresClasses maps SQLSTATE classes to error definitions, for codes without a mapping
+	postgresClasses = map[string]*ErrorDefinition{
+		"08": errDefDatabaseUnavailable, // connection_exception
+		"22": errDefInvalidFormat,       // data_exception
+		"23": errDefInvalidReference,    // integrity_constraint_violation
+	}
+	// mysqlCodes maps MySQL error numbers to error definitions
+	mysqlCodes = map[int64]*ErrorDefinition{
+		1062: errDefDuplicate,           // ER_DUP_ENTRY
+		1586: errDefDuplicate,           // ER_DUP_ENTRY_WITH_KEY_NAME
+		1451: errDefResourceInUse,       // ER_ROW_IS_REFERENCED_2
+		1452: errDefInvalidReference,    // ER_NO_REFERENCED_ROW_2
+		1048: errDefMissingData,         // ER_BAD_NULL_ERROR
+		1364: errDefMissingData,         // ER_NO_DEFAULT_FOR_FIELD
+		1406: errDefInvalidFormat,       // ER_DATA_TOO_LONG
+		1264: errDefInvalidFormat,       // ER_WARN_DATA_OUT_OF_RANGE
+		1366: errDefInvalidFormat,       // ER_TRUNCATED_WRONG_VALUE_FOR_FIELD
+		3819: errDefCheckViolation,      // ER_CHECK_CONSTRAINT_VIOLATED
+		1213: errDefConcurrentUpdate,    // ER_LOCK_DEADLOCK
+		1205: errDefConcurrentUpdate,    // ER_LOCK_WAIT_TIMEOUT
+		3024: errDefDatabaseTimeout,     // ER_QUERY_TIMEOUT
+		1040: errDefDatabaseUnavailable, // ER_CON_COUNT_ERROR
+	}
+	// sqliteCodes maps SQLite extended and primary result codes to error definitions
+	sqliteCodes = map[int64]*ErrorDefinition{
+		2067: errDefDuplicate,        // SQLITE_CONSTRAINT_UNIQUE
+		1555: errDefDuplicate,        // SQLITE_CONSTRAINT_PRIMARYKEY
+		787:  errDefInvalidReference, // SQLITE_CONSTRAINT_FOREIGNKEY
+		1299: errDefMissingData,      // SQLITE_CONSTRAINT_NOTNULL
+		275:  errDefCheckViolation,   // SQLITE_CONSTRAINT_CHECK
+		5:    errDefConcurrentUpdate, // SQLITE_BUSY
+		6:    errDefConcurrentUpdate, // SQLITE_LOCKED
+	}
+
+	postgresKeyPattern     = regexp.MustCompile(`Key \(([^)]+)\)=`)
+	mysqlKeyPattern        = regexp.MustCompile("for key '([^']+)'")
+	mysqlColumnPattern     = regexp.MustCompile("(?i)column '([^']+)'")
+	mysqlConstraintPattern = regexp.MustCompile("CONSTRAINT [`']([^`']+)[`']")
+	sqliteColumnPattern    = regexp.MustCompile(`constraint failed: ([\w.]+)`)
+)
+
+// RegisterDatabaseErrorClassifier adds a classifier that runs before the built-in ones
+// for Postgres, MySQL, SQLite and database/sql
+func RegisterDatabaseErrorClassifier(classifier DatabaseErrorClassifier) {
+	databaseClassifiers.Lock()
+	defer databaseClassifiers.Unlock()
+	databaseClassifiers.registered = append(databaseClassifiers.registered, classifier)
+}
+
+// ClassifyDatabaseError recognizes database errors anywhere in the chain of err by their
+// driver error types and codes
+func ClassifyDatabaseError(err error) (*DatabaseError, bool) {
+	databaseClassifiers.RLock()
+	classifiers := append([]DatabaseErrorClassifier{}, databaseClassifiers.registered...)
+	databaseClassifiers.RUnlock()
+
+	classifiers = append(classifiers, ClassifySQLError, ClassifyPostgresError, ClassifyMySQLError, ClassifySQLiteError)
+	for _, classify := range classifiers {
+		if dbErr, ok := classify(err); ok {
+			return dbErr, true
+		}
+	}
+	return nil, false
+}
+
+// Field names the error entry after the offending column or the violated constraint
+func (e *DatabaseError) Field() string {
+	switch {
+	case e.Column != "":
+		return e.Column
+	case e.Constraint != "":
+		return e.Constraint
+	default:
+		return "database"
+	}
+}
+
+// ClassifySQLError classifies the errors of database/sql
+func ClassifySQLError(err error) (*DatabaseError, bool) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return &DatabaseError{Definition: errDefNotFound}, true
+	case errors.Is(err, sql.ErrConnDone), errors.Is(err, sql.ErrTxDone):
+		return &DatabaseError{Definition: errDefDatabaseUnavailable}, true
+	default:
+		return nil, false
+	}
+}
+
+// ClassifyPostgresError classifies errors with a SQLSTATE code, such as the errors of
+// pgx and lib/pq
+func ClassifyPostgresError(err error) (*DatabaseError, bool) {
+	var pgErr interface{ SQLState() string }
+	if !errors.As(err, &pgErr) {
+		return nil, false
+	}
+
+	code := pgErr.SQLState()
+	def, ok := postgresCodes[code]
+	if !ok && len(code) == 5 {
+		def, ok = postgresClasses[code[:2]]
+	}
+	if !ok {
+		def = errDefDatabase
+	}
+
+	dbErr := &DatabaseError{
+		Definition: def,
+		Constraint: stringField(pgErr, "ConstraintName", "Constraint"),
+		Column:     stringField(pgErr, "ColumnName", "Column"),
+		Table:      stringField(pgErr, "TableName", "Table"),
+	}
+	if match := postgresKeyPattern.FindStringSubmatch(stringField(pgErr, "Detail")); dbErr.Column == "" && match != nil {
+		dbErr.Column = match[1]
+	}
+	return dbErr, true
+}
+
+// ClassifyMySQLError classifies the *mysql.MySQLError errors of go-sql-driver/mysql by
+// their error number
+func ClassifyMySQLError(err error) (*DatabaseError, bool) {
+	mysqlErr := findError(err, func(e error) bool {
+		value, ok := structValue(e)
+		_, hasNumber := intField(e, "Number")
+		return ok && hasNumber && value.Type().Name() == "MySQLError"
+	})
+	if mysqlErr == nil {
+		return nil, false
+	}
+
+	number, _ := intField(mysqlErr, "Number")
+	def, ok := mysqlCodes[number]
+	if !ok {
+		def = errDefDatabase
+	}
+	message := mysqlErr.Error()
+	dbErr := &DatabaseError{Definition: def, Column: submatch(mysqlColumnPattern, message)}
+	if dbErr.Constraint = submatch(mysqlKeyPattern, message); dbErr.Constraint == "" {
+		dbErr.Constraint = submatch(mysqlConstraintPattern, message)
+	}
+	return dbErr, true
+}
+
+// ClassifySQLiteError classifies the errors of mattn/go-sqlite3 and modernc.org/sqlite
+// by their extended result code
+func ClassifySQLiteError(err error) (*DatabaseError, bool) {
+	sqliteErr := findError(err, isSQLiteError)
+	if sqliteErr == nil {
+		return nil, false
+	}
+
+	code, _ := sqliteCode(sqliteErr)
+	def, ok := sqliteCodes[code]
+	if !ok {
+		def, ok = sqliteCodes[code&0xff]
+	}
+	if !ok {
+		def = errDefDatabase
+	}
+
+	dbErr := &DatabaseError{Definition: def}
+	if target := submatch(sqliteColumnPattern, sqliteErr.Error()); target != "" {
+		table, column, found := strings.Cut(target, ".")
+		if !found {
+			table, column = "", table
+		}
+		dbErr.Table, dbErr.Column = table, column
+	}
+	return dbErr, true
+}
+
+// isSQLiteError matches mattn/go-sqlite3 errors by their code fields and modernc.org/sqlite
+// errors by their package
+func isSQLiteError(err error) bool {
+	if _, hasExtended := intField(err, "ExtendedCode"); hasExtended {
+		_, hasCode := intField(err, "Code")
+		return hasCode
+	}
+	value, ok := structValue(err)
+	_, hasCode := err.(interface{ Code() int })
+	return ok && hasCode && strings.Contains(value.Type().PkgPath(), "sqlite")
+}
+
+// sqliteCode returns the extended result code of a SQLite error
+func sqliteCode(err error) (int64, bool) {
+	if coder, ok := err.(interface{ Code() int }); ok {
+		return int64(coder.Code()), true
+	}
+	return intField(err, "ExtendedCode")
+}
+
+// findError returns the first error in the chain of err, including joined errors, that
+// matches
+func findError(err error, match func(error) bool) error {
+	if err == nil {
+		return nil
+	}
+	if match(err) {
+		return err
+	}
+	switch wrapped := err.(type) {
+	case interface{ Unwrap() error }:
+		return findError(wrapped.Unwrap(), match)
+	case interface{ Unwrap() []error }:
+		for _, inner := range wrapped.Unwrap() {
+			if found := findError(inner, match); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+// structValue returns the struct an error value or pointer holds
+func structValue(v interface{}) (reflect.Value, bool) {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	return value, value.IsValid() && value.Kind() == reflect.Struct
+}
+
+// stringField returns the first non-empty string field of a driver error among names
+func stringField(v interface{}, names ...string) string {
+	value, ok := structValue(v)
+	if !ok {
+		return ""
+	}
+	for _, name := range names {
+		field := value.FieldByName(name)
+		if field.IsValid() && field.Kind() == reflect.String && field.String() != "" {
+			return field.String()
+		}
+	}
+	return ""
+}
+
+// intField returns an integer field of a driver error
+func intField(v interface{}, name string) (int64, bool) {
+	value, ok := structValue(v)
+	if !ok {
+		return 0, false
+	}
+	field := value.FieldByName(name)
+	switch {
+	case field.CanInt():
+		return field.Int(), true
+	case field.CanUint():
+		return int64(field.Uint()), true
+	default:
+		return 0, false
+	}
+}
+
+func submatch(pattern *regexp.Regexp, s string) string {
+	if match := pattern.FindStringSubmatch(s); match != nil {
+		return match[1]
+	}
+	return ""
+}
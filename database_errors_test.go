@@ -0,0 +1,193 @@
+package goresponse
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pgxError mimics *pgconn.PgError
+type pgxError struct {
+	Code           string
+	Message        string
+	Detail         string
+	TableName      string
+	ColumnName     string
+	ConstraintName string
+}
+
+func (e *pgxError) Error() string    { return "ERROR: " + e.Message + " (SQLSTATE " + e.Code + ")" }
+func (e *pgxError) SQLState() string { return e.Code }
+
+// pqError mimics *pq.Error
+type pqError struct {
+	Code       string
+	Message    string
+	Table      string
+	Column     string
+	Constraint string
+}
+
+func (e *pqError) Error() string    { return "pq: " + e.Message }
+func (e *pqError) SQLState() string { return e.Code }
+
+// MySQLError mimics *mysql.MySQLError
+type MySQLError struct {
+	Number   uint16
+	SQLState [5]byte
+	Message  string
+}
+
+func (e *MySQLError) Error() string { return fmt.Sprintf("Error %d: %s", e.Number, e.Message) }
+
+// sqlite3Error mimics sqlite3.Error of mattn/go-sqlite3
+type sqlite3Error struct {
+	Code         int
+	ExtendedCode int
+	err          string
+}
+
+func (e sqlite3Error) Error() string { return e.err }
+
+var errPaymentDeclined = errors.New("payment declined")
+
+func TestClassifyDatabaseError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantField  string
+	}{
+		{
+			name: "pgx unique violation",
+			err: &pgxError{Code: "23505", Message: "duplicate key value violates unique constraint \"users_email_key\"",
+				Detail: "Key (email)=(jane@example.com) already exists.", TableName: "users", ConstraintName: "users_email_key"},
+			wantStatus: http.StatusConflict,
+			wantCode:   ErrCodeDuplicate,
+			wantField:  "email",
+		},
+		{
+			name:       "pq foreign key violation",
+			err:        fmt.Errorf("create order: %w", &pqError{Code: "23503", Constraint: "orders_user_id_fkey"}),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrCodeInvalidReference,
+			wantField:  "orders_user_id_fkey",
+		},
+		{
+			name:       "pgx not null violation",
+			err:        &pgxError{Code: "23502", ColumnName: "name"},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrCodeMissingData,
+			wantField:  "name",
+		},
+		{
+			name:       "pgx serialization failure",
+			err:        &pgxError{Code: "40001"},
+			wantStatus: http.StatusConflict,
+			wantCode:   ErrCodeConcurrentUpdate,
+			wantField:  "database",
+		},
+		{
+			name:       "pgx query canceled",
+			err:        &pgxError{Code: "57014"},
+			wantStatus: http.StatusGatewayTimeout,
+			wantCode:   ErrCodeDatabaseTimeout,
+			wantField:  "database",
+		},
+		{
+			name:       "pgx connection class",
+			err:        &pgxError{Code: "08006"},
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   ErrCodeDatabaseUnavailable,
+			wantField:  "database",
+		},
+		{
+			name:       "pgx unknown code",
+			err:        &pgxError{Code: "XX000"},
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   ErrCodeDatabase,
+			wantField:  "database",
+		},
+		{
+			name:       "mysql duplicate entry",
+			err:        &MySQLError{Number: 1062, Message: "Duplicate entry 'jane@example.com' for key 'users.email'"},
+			wantStatus: http.StatusConflict,
+			wantCode:   ErrCodeDuplicate,
+			wantField:  "users.email",
+		},
+		{
+			name: "mysql missing reference",
+			err: fmt.Errorf("create order: %w", &MySQLError{Number: 1452, Message: "Cannot add or update a child row: " +
+				"a foreign key constraint fails (`shop`.`orders`, CONSTRAINT `orders_user_fk` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`))"}),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrCodeInvalidReference,
+			wantField:  "orders_user_fk",
+		},
+		{
+			name:       "mysql null column",
+			err:        &MySQLError{Number: 1048, Message: "Column 'name' cannot be null"},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrCodeMissingData,
+			wantField:  "name",
+		},
+		{
+			name:       "sqlite unique",
+			err:        sqlite3Error{Code: 19, ExtendedCode: 2067, err: "UNIQUE constraint failed: users.email"},
+			wantStatus: http.StatusConflict,
+			wantCode:   ErrCodeDuplicate,
+			wantField:  "email",
+		},
+		{
+			name:       "sqlite busy",
+			err:        sqlite3Error{Code: 5, ExtendedCode: 261, err: "database is locked"},
+			wantStatus: http.StatusConflict,
+			wantCode:   ErrCodeConcurrentUpdate,
+			wantField:  "database",
+		},
+		{
+			name:       "database/sql no rows",
+			err:        fmt.Errorf("find user: %w", sql.ErrNoRows),
+			wantStatus: http.StatusNotFound,
+			wantCode:   ErrCodeNotFound,
+			wantField:  "database",
+		},
+		{
+			name:       "message mentioning a constraint",
+			err:        errors.New("password breaks the length constraint"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   ErrCodeInternal,
+			wantField:  "general",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := NewStandardErrorResponse(http.StatusBadRequest).AddError(tt.err)
+			assert.Equal(t, tt.wantStatus, response.Code)
+			assert.Equal(t, tt.wantCode, response.Errors[0]["code"])
+			assert.Equal(t, tt.wantField, response.Errors[0]["field"])
+		})
+	}
+}
+
+func TestRegisterDatabaseErrorClassifier(t *testing.T) {
+	RegisterDatabaseErrorClassifier(func(err error) (*DatabaseError, bool) {
+		if errors.Is(err, errPaymentDeclined) {
+			return &DatabaseError{Definition: errDefCheckViolation, Constraint: "payments_amount_check"}, true
+		}
+		return nil, false
+	})
+
+	dbErr, ok := ClassifyDatabaseError(fmt.Errorf("charge: %w", errPaymentDeclined))
+	assert.True(t, ok)
+	assert.Equal(t, ErrCodeCheckViolation, dbErr.Definition.Code)
+	assert.Equal(t, "payments_amount_check", dbErr.Field())
+
+	_, ok = ClassifyDatabaseError(errors.New("payment accepted"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,134 @@
+package goresponse
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// DebugInfo exposes an internal error in debug mode, never enable it in production
+type DebugInfo struct {
+	Error string   `json:"error"`
+	Chain []string `json:"chain,omitempty"` // Wrapped errors, outermost first
+	Stack []string `json:"stack,omitempty"` // Stack where the error was added to the response
+}
+
+// debugMode holds the settings of SetDebugMode, the environment is used until it's called
+var debugMode struct {
+	sync.RWMutex
+	set        bool
+	enabled    bool
+	stackTrace bool
+}
+
+// SetDebugMode includes internal errors, their chain and optionally the stack trace in
+// the debug section of responses. It overrides GetDebugModeFromEnv.
+func SetDebugMode(enabled, stackTrace bool) {
+	debugMode.Lock()
+	defer debugMode.Unlock()
+	debugMode.set, debugMode.enabled, debugMode.stackTrace = true, enabled, enabled && stackTrace
+}
+
+// GetDebugModeFromEnv reads the debug mode from ERROR_DEBUG_MODE: true enables it and
+// stack also includes stack traces
+func GetDebugModeFromEnv() (enabled, stackTrace bool) {
+	switch strings.ToLower(os.Getenv("ERROR_DEBUG_MODE")) {
+	case "true", "1":
+		return true, false
+	case "stack":
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+func debugSettings() (enabled, stackTrace bool) {
+	debugMode.RLock()
+	defer debugMode.RUnlock()
+	if debugMode.set {
+		return debugMode.enabled, debugMode.stackTrace
+	}
+	return GetDebugModeFromEnv()
+}
+
+// addInternalError reports an unexpected error with a generic message and a reference ID
+// clients can quote, the real error is logged under that ID when the response is sent
+func (ser *StandardErrorResponse) addInternalError(field string, err error) {
+	if ser.ReferenceID == "" {
+		ser.ReferenceID = uuid.NewString()
+	}
+	if ser.internalErr == nil {
+		ser.internalErr = err
+	}
+
+	ser.appendLocalizedError(field, errDefInternal.Code, errDefInternal.localizedMessage)
+	ser.raiseStatus(errDefInternal.Status)
+
+	if enabled, stackTrace := debugSettings(); enabled && ser.Debug == nil {
+		ser.Debug = &DebugInfo{Error: err.Error(), Chain: errorChain(err)}
+		ser.Debug.Stack = debugStack(err, stackTrace)
+	}
+}
+
+// logInternalError logs the unknown error of the response with slog.Default(), unless
+// the error handler logged it already
+func (ser *StandardErrorResponse) logInternalError(c echo.Context) {
+	if ser.internalErr == nil {
+		return
+	}
+	slog.Default().LogAttrs(c.Request().Context(), slog.LevelError, "internal error",
+		slog.String("reference_id", ser.ReferenceID),
+		slog.String("request_id", ser.RequestID),
+		slog.String("error", ser.internalErr.Error()),
+	)
+	ser.internalErr = nil
+}
+
+// debugStack returns the stack of the debug section: where a panic happened, or where
+// the error was added when stack traces are enabled
+func debugStack(err error, stackTrace bool) []string {
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) && (stackTrace || panicErr.debugStack) {
+		return stackLines(panicErr.Stack)
+	}
+	if stackTrace {
+		return stackLines(debug.Stack())
+	}
+	return nil
+}
+
+func stackLines(stack []byte) []string {
+	return strings.Split(strings.TrimSpace(string(stack)), "\n")
+}
+
+// errorChain describes every error wrapped by err, including joined ones
+func errorChain(err error) []string {
+	var chain []string
+	for _, wrapped := range unwrapAll(err) {
+		chain = append(chain, fmt.Sprintf("%T: %s", wrapped, wrapped.Error()))
+	}
+	return chain
+}
+
+func unwrapAll(err error) []error {
+	if err == nil {
+		return nil
+	}
+	chain := []error{err}
+	switch wrapped := err.(type) {
+	case interface{ Unwrap() error }:
+		chain = append(chain, unwrapAll(wrapped.Unwrap())...)
+	case interface{ Unwrap() []error }:
+		for _, inner := range wrapped.Unwrap() {
+			chain = append(chain, unwrapAll(inner)...)
+		}
+	}
+	return chain
+}
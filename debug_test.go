@@ -0,0 +1,126 @@
+package goresponse
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func resetDebugMode() {
+	debugMode.Lock()
+	defer debugMode.Unlock()
+	debugMode.set, debugMode.enabled, debugMode.stackTrace = false, false, false
+}
+
+func TestInternalErrorsAreNotLeaked(t *testing.T) {
+	err := fmt.Errorf("load avatar: %w", errors.New("open /var/lib/app/avatars/42.png: permission denied"))
+	response := NewStandardErrorResponse(http.StatusBadRequest).AddError(err)
+
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+	assert.Equal(t, "An unexpected error occurred", response.Errors[0]["message"])
+	assert.NotEmpty(t, response.ReferenceID)
+	assert.Nil(t, response.Debug)
+
+	raw, _ := json.Marshal(response)
+	assert.NotContains(t, string(raw), "/var/lib")
+}
+
+func TestInternalErrorsAreLoggedOnce(t *testing.T) {
+	err := errors.New("open /var/lib/app/avatars/42.png: permission denied")
+	tests := []struct {
+		name string
+		send func(c echo.Context) error
+	}{
+		{
+			name: "AddError then JSON",
+			send: func(c echo.Context) error {
+				return NewStandardErrorResponse(http.StatusBadRequest).AddError(err).JSON(c)
+			},
+		},
+		{
+			name: "error handler without a logger",
+			send: func(c echo.Context) error {
+				NewErrorHandler(ErrorHandlerConfig{})(err, c)
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := captureDefaultLogs(t)
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echo.HeaderXRequestID, "req-1")
+			rec := httptest.NewRecorder()
+
+			assert.NoError(t, tt.send(echo.New().NewContext(req, rec)))
+
+			var body StandardErrorResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, 1, strings.Count(logs.String(), "\n"))
+			var entry map[string]interface{}
+			assert.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+			assert.Equal(t, body.ReferenceID, entry["reference_id"])
+			assert.Equal(t, "req-1", entry["request_id"])
+			assert.Equal(t, err.Error(), entry["error"])
+		})
+	}
+}
+
+func TestDebugMode(t *testing.T) {
+	t.Cleanup(resetDebugMode)
+
+	cause := errors.New("dial tcp 10.0.0.5:5432: connection refused")
+	err := fmt.Errorf("load user: %w", errors.Join(cause, errors.New("retry failed")))
+
+	SetDebugMode(true, false)
+	response := NewStandardErrorResponse(http.StatusBadRequest).AddError(err)
+	assert.Equal(t, "An unexpected error occurred", response.Errors[0]["message"])
+	assert.Equal(t, &DebugInfo{
+		Error: err.Error(),
+		Chain: []string{
+			"*fmt.wrapError: " + err.Error(),
+			"*errors.joinError: dial tcp 10.0.0.5:5432: connection refused\nretry failed",
+			"*errors.errorString: dial tcp 10.0.0.5:5432: connection refused",
+			"*errors.errorString: retry failed",
+		},
+	}, response.Debug)
+
+	SetDebugMode(true, true)
+	response = NewStandardErrorResponse(http.StatusBadRequest).AddError(err)
+	assert.NotEmpty(t, response.Debug.Stack)
+	assert.Contains(t, response.Problem("").Extensions, "debug")
+
+	SetDebugMode(false, true)
+	assert.Nil(t, NewStandardErrorResponse(http.StatusBadRequest).AddError(err).Debug)
+}
+
+func TestGetDebugModeFromEnv(t *testing.T) {
+	tests := []struct {
+		value      string
+		enabled    bool
+		stackTrace bool
+	}{
+		{value: "", enabled: false, stackTrace: false},
+		{value: "true", enabled: true, stackTrace: false},
+		{value: "1", enabled: true, stackTrace: false},
+		{value: "STACK", enabled: true, stackTrace: true},
+		{value: "no", enabled: false, stackTrace: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("ERROR_DEBUG_MODE", tt.value)
+			enabled, stackTrace := GetDebugModeFromEnv()
+			assert.Equal(t, tt.enabled, enabled)
+			assert.Equal(t, tt.stackTrace, stackTrace)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package goresponse
+
+import "strings"
+
+// WithNestedDynamicFields makes ParseURLValues build nested maps from deepObject style
+// bracket paths, so filter[author][name]=x is stored as a tree under DynamicFields["filter"]
+// instead of the opaque key "filter[author][name]". A trailing [] always yields a slice.
+func WithNestedDynamicFields() ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.nestedDynamicFields = true
+	}
+}
+
+// splitBracketPath splits filter[author][name] into [filter author name]
+func splitBracketPath(param string) ([]string, bool) {
+	open := strings.IndexByte(param, '[')
+	if open <= 0 || !strings.HasSuffix(param, "]") {
+		return nil, false
+	}
+
+	segments := []string{param[:open]}
+	rest := param[open:]
+	for rest != "" {
+		end := strings.IndexByte(rest, ']')
+		if rest[0] != '[' || end < 0 {
+			return nil, false
+		}
+		segment := rest[1:end]
+		if strings.ContainsRune(segment, '[') {
+			return nil, false
+		}
+		segments = append(segments, segment)
+		rest = rest[end+1:]
+	}
+	return segments, true
+}
+
+// joinBracketPath appends a segment to a bracket path, e.g. filter + author = filter[author]
+func joinBracketPath(prefix, segment string) string {
+	if prefix == "" {
+		return segment
+	}
+	return prefix + "[" + segment + "]"
+}
+
+// handleDynamicListField converts values of a [] suffixed param, which is always a slice
+func handleDynamicListField(values []string) interface{} {
+	if uuidSlice, ok := handleDynamicUUIDSlice(values); ok {
+		return uuidSlice
+	}
+	return values
+}
+
+// setDynamicPath stores the values of param in fields following the given path segments
+func setDynamicPath(fields map[string]interface{}, param string, segments []string, values []string) error {
+	leafValue := handleDynamicField(values)
+	if segments[len(segments)-1] == "" {
+		segments = segments[:len(segments)-1]
+		leafValue = handleDynamicListField(values)
+	}
+
+	node := fields
+	for i, segment := range segments {
+		if segment == "" {
+			return &QueryParamError{Param: param, Message: "Empty path segment"}
+		}
+
+		child, exists := node[segment]
+		if i == len(segments)-1 {
+			if exists {
+				return &QueryParamError{Param: param, Message: "Path conflicts with another parameter"}
+			}
+			node[segment] = leafValue
+			return nil
+		}
+
+		if !exists {
+			child = make(map[string]interface{})
+			node[segment] = child
+		}
+		nested, ok := child.(map[string]interface{})
+		if !ok {
+			return &QueryParamError{Param: param, Message: "Path conflicts with another parameter"}
+		}
+		node = nested
+	}
+	return nil
+}
+
+// GetDynamicPath returns a nested dynamic field by its dot separated path, e.g.
+// "filter.author.name" for filter[author][name]. Fields parsed without
+// WithNestedDynamicFields are found through their flat bracket key as well.
+func (f *FilterOptions) GetDynamicPath(path string) (interface{}, bool) {
+	if f.DynamicFields == nil || path == "" {
+		return nil, false
+	}
+
+	segments := strings.Split(path, ".")
+	if value, exists := lookupDynamicPath(f.DynamicFields, segments); exists {
+		return value, true
+	}
+
+	flatKey := ""
+	for _, segment := range segments {
+		flatKey = joinBracketPath(flatKey, segment)
+	}
+	value, exists := f.DynamicFields[flatKey]
+	return value, exists
+}
+
+func lookupDynamicPath(fields map[string]interface{}, segments []string) (interface{}, bool) {
+	var value interface{} = fields
+	for _, segment := range segments {
+		node, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = node[segment]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
@@ -0,0 +1,171 @@
+package goresponse
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseURLValuesNestedDynamicFields(t *testing.T) {
+	tests := []struct {
+		name        string
+		urlValues   url.Values
+		want        map[string]interface{}
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name: "deep object",
+			urlValues: url.Values{
+				"filter[author][name]": []string{"jane"},
+				"filter[author][id]":   []string{"123e4567-e89b-12d3-a456-426614174000"},
+				"filter[status]":       []string{"published"},
+			},
+			want: map[string]interface{}{
+				"filter": map[string]interface{}{
+					"author": map[string]interface{}{
+						"name": "jane",
+						"id":   uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+					},
+					"status": "published",
+				},
+			},
+		},
+		{
+			name: "trailing brackets always produce a slice",
+			urlValues: url.Values{
+				"filter[tags][]": []string{"go"},
+			},
+			want: map[string]interface{}{
+				"filter": map[string]interface{}{
+					"tags": []string{"go"},
+				},
+			},
+		},
+		{
+			name: "plain params stay flat",
+			urlValues: url.Values{
+				"audio_id": []string{"abc"},
+			},
+			want: map[string]interface{}{
+				"audio_id": "abc",
+			},
+		},
+		{
+			name: "leaf and object on the same path",
+			urlValues: url.Values{
+				"filter[author]":       []string{"jane"},
+				"filter[author][name]": []string{"jane"},
+			},
+			wantErr:     true,
+			errContains: "invalid filter[author][name] parameter: Path conflicts with another parameter",
+		},
+		{
+			name: "conflicting nested paths",
+			urlValues: url.Values{
+				"filter[a]":    []string{"1"},
+				"filter[a][b]": []string{"2"},
+			},
+			wantErr:     true,
+			errContains: "invalid filter[a][b] parameter: Path conflicts with another parameter",
+		},
+		{
+			name: "empty path segment",
+			urlValues: url.Values{
+				"filter[][name]": []string{"jane"},
+			},
+			wantErr:     true,
+			errContains: "invalid filter[][name] parameter: Empty path segment",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseURLValues(tt.urlValues, WithNestedDynamicFields())
+
+			if tt.wantErr {
+				var paramErr *QueryParamError
+				assert.ErrorAs(t, err, &paramErr)
+				assert.Contains(t, err.Error(), tt.errContains)
+				assert.Equal(t, http.StatusBadRequest, NewFromError(err).Code)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.DynamicFields)
+		})
+	}
+}
+
+func TestFilterOptions_GetDynamicPath(t *testing.T) {
+	nested, err := ParseURLValues(url.Values{"filter[author][name]": []string{"jane"}}, WithNestedDynamicFields())
+	assert.NoError(t, err)
+	flat, err := ParseURLValues(url.Values{"filter[author][name]": []string{"jane"}})
+	assert.NoError(t, err)
+
+	for _, f := range []*FilterOptions{nested, flat} {
+		value, ok := f.GetDynamicPath("filter.author.name")
+		assert.True(t, ok)
+		assert.Equal(t, "jane", value)
+
+		_, ok = f.GetDynamicPath("filter.author.email")
+		assert.False(t, ok)
+	}
+
+	_, ok := (&FilterOptions{}).GetDynamicPath("filter")
+	assert.False(t, ok)
+}
+
+func TestGenerateCacheKeyNestedDynamicFields(t *testing.T) {
+	values := url.Values{
+		"filter[author][name]": []string{"jane"},
+		"filter[tags]":         []string{"go", "api"},
+	}
+	nested, err := ParseURLValues(values, WithNestedDynamicFields())
+	assert.NoError(t, err)
+	flat, err := ParseURLValues(values)
+	assert.NoError(t, err)
+	other, err := ParseURLValues(url.Values{"filter[author][name]": []string{"john"}}, WithNestedDynamicFields())
+	assert.NoError(t, err)
+
+	assert.Equal(t, nested.GenerateCacheKey("test:"), nested.GenerateCacheKey("test:"))
+	assert.Equal(t, flat.GenerateCacheKey("test:"), nested.GenerateCacheKey("test:"),
+		"Nested and flat bracket paths should generate the same key")
+	assert.NotEqual(t, other.GenerateCacheKey("test:"), nested.GenerateCacheKey("test:"))
+}
+
+func TestFilterOptions_URLValues(t *testing.T) {
+	values := url.Values{
+		"page":                 []string{"2"},
+		"limit":                []string{"10"},
+		"q":                    []string{"search text"},
+		"categories":           []string{"b,a"},
+		"user_id":              []string{"123e4567-e89b-12d3-a456-426614174000"},
+		"filter[author][name]": []string{"jane"},
+		"filter[tags][]":       []string{"go", "api"},
+		"filter[ids][]":        []string{"a"},
+		"color":                []string{"red", "blue"},
+	}
+	filter, err := ParseURLValues(values, WithNestedDynamicFields())
+	assert.NoError(t, err)
+
+	encoded := filter.URLValues()
+	assert.Equal(t, "2", encoded.Get("page"))
+	assert.Equal(t, "10", encoded.Get("offset"))
+	assert.Equal(t, "DESC", encoded.Get("sort"))
+	assert.Equal(t, "a,b", encoded.Get("categories"))
+	assert.Equal(t, "jane", encoded.Get("filter[author][name]"))
+	assert.Equal(t, []string{"api", "go"}, encoded["filter[tags][]"])
+	assert.Equal(t, []string{"a"}, encoded["filter[ids][]"])
+	assert.Equal(t, []string{"blue", "red"}, encoded["color"])
+
+	roundTrip, err := ParseURLValues(encoded, WithNestedDynamicFields())
+	assert.NoError(t, err)
+	assert.Equal(t, filter.GenerateCacheKey("test:"), roundTrip.GenerateCacheKey("test:"))
+	assert.Equal(t, encoded.Encode(), roundTrip.URLValues().Encode())
+	ids, _ := roundTrip.GetDynamicPath("filter.ids")
+	assert.Equal(t, []string{"a"}, ids, "Single value lists stay slices")
+}
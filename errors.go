@@ -1,12 +1,12 @@
 package goresponse
 
 import (
-	"database/sql"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
@@ -14,17 +14,42 @@ import (
 
 // StandardErrorResponse defines the structure of the error response
 type StandardErrorResponse struct {
-	Code      int                 `json:"code"`
-	Message   string              `json:"message"`
-	Errors    []map[string]string `json:"errors"`
-	RequestID string              `json:"request_id,omitempty"`
+	Code        int                 `json:"code"`
+	Message     string              `json:"message"`
+	Errors      []map[string]string `json:"errors"`
+	RequestID   string              `json:"request_id,omitempty"`
+	ReferenceID string              `json:"reference_id,omitempty"` // Identifies an internal error in the server logs
+	Debug       *DebugInfo          `json:"debug,omitempty"`
+
+	RetryAfterSeconds int `json:"retry_after_seconds,omitempty"` // When a throttled or unavailable request may be retried
+
+	format        ErrorFormat
+	statusSet     bool                               // Whether an added error set Code
+	locale        string                             // Locale of the messages, default locale until set
+	renderMessage func(locale string) string         // Renders Message while it's the status default
+	renderErrors  map[int]func(locale string) string // Renders the localizable entries of Errors
+	rateLimit     *RateLimit                         // Sent as RateLimit headers
+	generalField  string                             // Field of entries not about a field, "general" when empty
+	internalErr   error                              // Unknown error logged when the response is sent
 }
 
 // HTTPError represents custom error types
 type HTTPError struct {
-	Code     int
-	Message  string
-	Internal error
+	Code      int
+	Message   string
+	ErrorCode string        // Stable machine-readable code, e.g. USER_EMAIL_TAKEN
+	Fields    []FieldDetail // Reported as one entry per field instead of the message
+	Internal  error
+
+	RetryAfter time.Duration // When the client may retry, sent as Retry-After
+	RateLimit  *RateLimit    // Quota of the client, sent as RateLimit headers
+}
+
+// FieldDetail describes what's wrong with one field of an HTTPError
+type FieldDetail struct {
+	Field   string
+	Code    string // The error's code when empty
+	Message string
 }
 
 // Error implements the error interface
@@ -32,119 +57,296 @@ func (e *HTTPError) Error() string {
 	return e.Message
 }
 
-// NewStandardErrorResponse creates a new instance of StandardErrorResponse
+// Unwrap returns the internal cause, so errors.Is and errors.As see through it
+func (e *HTTPError) Unwrap() error {
+	return e.Internal
+}
+
+// QueryParamError reports an invalid query parameter, e.g. a malformed search query
+type QueryParamError struct {
+	Param   string
+	Message string
+}
+
+// Error implements the error interface
+func (e *QueryParamError) Error() string {
+	return fmt.Sprintf("invalid %s parameter: %s", e.Param, e.Message)
+}
+
+// NewStandardErrorResponse creates a new instance of StandardErrorResponse. statusCode is
+// only the default: the first added error replaces it with its own status, and the default
+// message follows, see raiseStatus.
 func NewStandardErrorResponse(statusCode int) *StandardErrorResponse {
-	return &StandardErrorResponse{
-		Code:    statusCode,
-		Message: getDefaultMessageForStatus(statusCode),
-		Errors:  []map[string]string{},
+	ser := &StandardErrorResponse{
+		Code:   statusCode,
+		Errors: []map[string]string{},
+		renderMessage: func(locale string) string {
+			return statusMessage(locale, statusCode)
+		},
 	}
+	ser.Message = ser.renderMessage(defaultLocale())
+	return ser
 }
 
 // getDefaultMessageForStatus returns user-friendly messages for HTTP status codes
 func getDefaultMessageForStatus(code int) string {
-	switch code {
-	case http.StatusBadRequest:
-		return "We couldn't process your request due to invalid input"
-	case http.StatusUnauthorized:
-		return "Please authenticate to access this resource"
-	case http.StatusForbidden:
-		return "You don't have permission to access this resource"
-	case http.StatusNotFound:
-		return "The requested resource couldn't be found"
-	case http.StatusConflict:
-		return "This operation conflicts with an existing resource"
-	case http.StatusUnprocessableEntity:
-		return "The submitted data failed validation"
-	case http.StatusTooManyRequests:
-		return "You've exceeded the allowed number of requests. Please try again later"
-	case http.StatusInternalServerError:
-		return "An unexpected error occurred. Our team has been notified"
-	case http.StatusServiceUnavailable:
-		return "The service is temporarily unavailable. Please try again later"
-	default:
-		return http.StatusText(code)
-	}
+	return statusMessage(defaultLocale(), code)
 }
 
 // ResetErrors resets the errors slice in the response
 func (ser *StandardErrorResponse) ResetErrors() {
 	ser.Errors = []map[string]string{}
+	ser.renderErrors = nil
 }
 
-// AddError adds an error to the response with improved error handling
+// AddError adds an error to the response with improved error handling. Joined errors
+// are added one by one and the most severe status wins, see raiseStatus.
 func (ser *StandardErrorResponse) AddError(err error) *StandardErrorResponse {
-	switch e := err.(type) {
-	case validator.ValidationErrors:
-		for _, validationErr := range e {
-			ser.Errors = append(ser.Errors, map[string]string{
-				"field":   toSnakeCase(validationErr.Field()),
-				"message": getValidationErrorMessage(validationErr),
+	unknown := false
+	for _, part := range joinedErrors(err) {
+		if !ser.addKnownError(part) {
+			unknown = true
+		}
+	}
+	if unknown {
+		// Unknown errors may carry internal details, so they aren't shown outside debug mode
+		ser.addInternalError(ser.generalFieldName(), err)
+	}
+	return ser
+}
+
+// generalFieldName returns the field of the entries that aren't about a field
+func (ser *StandardErrorResponse) generalFieldName() string {
+	if ser.generalField != "" {
+		return ser.generalField
+	}
+	return "general"
+}
+
+// addKnownError adds err unless the package doesn't know it, then it reports false
+func (ser *StandardErrorResponse) addKnownError(err error) bool {
+	var (
+		httpErr        *HTTPError
+		validationErrs validator.ValidationErrors
+		paramErr       *QueryParamError
+	)
+
+	// Errors are matched through wrap chains, an HTTPError wins over the cause it wraps
+	switch {
+	case errors.As(err, &httpErr):
+		ser.addHTTPError(httpErr)
+	case errors.As(err, &validationErrs):
+		ser.raiseStatus(http.StatusUnprocessableEntity)
+		for _, validationErr := range validationErrs {
+			ser.appendLocalizedError(validationFieldName(validationErr), validationErrorCode(validationErr), func(locale string) string {
+				return validationMessage(locale, validationErr)
 			})
 		}
-	case *json.UnmarshalTypeError:
-		ser.Errors = append(ser.Errors, map[string]string{
-			"field":   toSnakeCase(e.Field),
-			"message": fmt.Sprintf("Invalid value for %s. Expected %s", e.Field, e.Type.String()),
-		})
+	case errors.As(err, &paramErr):
+		ser.appendError(paramErr.Param, errDefInvalidParameter.Code, paramErr.Message)
+		ser.raiseStatus(errDefInvalidParameter.Status)
 	default:
-		// Check if the error is a database error
-		if isDatabaseError(err) {
-			statusCode, dbMessage := getDatabaseErrorResponse(err)
-			ser.Code = statusCode
-			ser.Errors = append(ser.Errors, map[string]string{
-				"field":   "database",
-				"message": dbMessage,
-			})
-		} else {
-			// General error handling
-			ser.Errors = append(ser.Errors, map[string]string{
-				"field":   "general",
-				"message": err.Error(),
-			})
-			ser.Code = http.StatusInternalServerError
+		return ser.addClassifiedError(err)
+	}
+	return true
+}
+
+// addClassifiedError adds request body errors, Echo errors, canceled and timed out
+// requests and database errors
+func (ser *StandardErrorResponse) addClassifiedError(err error) bool {
+	// Echo's bind errors wrap body errors, which describe them better
+	if entry, ok := classifyBodyError(err); ok {
+		ser.raiseStatus(entry.def.Status)
+		ser.appendLocalizedError(entry.field, entry.def.Code, entry.render)
+		return true
+	}
+	// An Echo error wins over the cause it wraps, like HTTPError
+	var echoErr *echo.HTTPError
+	if errors.As(err, &echoErr) {
+		ser.raiseStatus(echoErr.Code)
+		ser.appendError("error", statusErrorCode(echoErr.Code), fmt.Sprintf("%v", echoErr.Message))
+		return true
+	}
+	if def, ok := ClassifyContextError(err); ok {
+		ser.raiseStatus(def.Status)
+		ser.appendLocalizedError(ser.generalFieldName(), def.Code, def.localizedMessage)
+		return true
+	}
+	// Check if the error is a database error
+	if dbErr, ok := ClassifyDatabaseError(err); ok {
+		ser.raiseStatus(dbErr.Definition.Status)
+		ser.appendLocalizedError(dbErr.Field(), dbErr.Definition.Code, dbErr.Definition.localizedMessage)
+		return true
+	}
+	return false
+}
+
+// addHTTPError adds an entry per field detail of e, or a single entry with its message
+func (ser *StandardErrorResponse) addHTTPError(e *HTTPError) {
+	ser.raiseStatus(e.Code)
+	ser.addRetryInfo(e)
+	if len(e.Fields) == 0 {
+		ser.appendLocalizedError("error", e.errorCode(), e.localizedMessage)
+		return
+	}
+	for _, detail := range e.Fields {
+		code := detail.Code
+		if code == "" {
+			code = e.errorCode()
 		}
+		ser.appendError(detail.Field, code, detail.Message)
 	}
-	return ser
 }
 
-// AddMessageError adds a manual error to the response
-func (ser *StandardErrorResponse) AddMessageError(field string, message string) *StandardErrorResponse {
+// appendError adds a field error entry carrying its machine-readable code, unless an
+// entry with the same field and message exists
+func (ser *StandardErrorResponse) appendError(field, code, message string) bool {
+	for _, entry := range ser.Errors {
+		if entry["field"] == field && entry["message"] == message {
+			return false
+		}
+	}
 	ser.Errors = append(ser.Errors, map[string]string{
 		"field":   field,
+		"code":    code,
 		"message": message,
 	})
+	return true
+}
+
+// appendLocalizedError adds a field error entry whose message is rendered in the
+// response locale
+func (ser *StandardErrorResponse) appendLocalizedError(field, code string, render func(locale string) string) {
+	if !ser.appendError(field, code, render(ser.localeOrDefault())) {
+		return
+	}
+	if ser.renderErrors == nil {
+		ser.renderErrors = make(map[int]func(locale string) string)
+	}
+	ser.renderErrors[len(ser.Errors)-1] = render
+}
+
+// AddMessageError adds a manual error to the response, coded after the response status
+func (ser *StandardErrorResponse) AddMessageError(field string, message string) *StandardErrorResponse {
+	ser.appendError(field, statusErrorCode(ser.Code), message)
+	return ser
+}
+
+// AddCodedError adds a manual error with a machine-readable code to the response
+func (ser *StandardErrorResponse) AddCodedError(field, code, message string) *StandardErrorResponse {
+	ser.appendError(field, code, message)
+	return ser
+}
+
+// WithFormat selects how JSON renders the response, see ErrorFormat
+func (ser *StandardErrorResponse) WithFormat(format ErrorFormat) *StandardErrorResponse {
+	ser.format = format
+	return ser
+}
+
+// WithLocale renders the default and package messages of the response in locale.
+// Custom messages are kept as they are.
+func (ser *StandardErrorResponse) WithLocale(locale string) *StandardErrorResponse {
+	if ser.renderMessage != nil && ser.Message == ser.renderMessage(ser.localeOrDefault()) {
+		ser.Message = ser.renderMessage(locale)
+	}
+	for i, render := range ser.renderErrors {
+		if i < len(ser.Errors) {
+			ser.Errors[i]["message"] = render(locale)
+		}
+	}
+	ser.locale = locale
 	return ser
 }
 
-// JSON sends the response with request tracking and documentation
+// localeOrDefault returns the locale the messages are rendered in
+func (ser *StandardErrorResponse) localeOrDefault() string {
+	if ser.locale != "" {
+		return ser.locale
+	}
+	return defaultLocale()
+}
+
+// JSON sends the response with request tracking and documentation. Messages are
+// localized for the request unless WithLocale was used.
 func (ser *StandardErrorResponse) JSON(c echo.Context) error {
+	return ser.sendJSON(c, ser.format.useProblem(c))
+}
+
+// sendJSON sends the response as JSON, or as problem details when problem is set
+func (ser *StandardErrorResponse) sendJSON(c echo.Context, problem bool) error {
+	if problem {
+		return ser.ProblemJSON(c)
+	}
+	ser.prepare(c)
+	return c.JSON(ser.Code, ser)
+}
+
+// prepare completes the response for the request before it's sent in any format
+func (ser *StandardErrorResponse) prepare(c echo.Context) {
 	// Add request tracking ID if available
-	if reqID := c.Request().Header.Get("X-Request-ID"); reqID != "" {
+	if reqID := requestID(c); reqID != "" {
 		ser.RequestID = reqID
 	}
-	return c.JSON(ser.Code, ser)
+	ser.logInternalError(c)
+	if ser.locale == "" {
+		ser.WithLocale(ResolveLocale(c))
+	}
+	ser.setRetryHeaders(c.Response().Header())
+}
+
+// ErrorHandlerConfig configures the error handler created by NewErrorHandler
+type ErrorHandlerConfig struct {
+	// Format of the error responses, ErrorFormatStandard by default
+	Format ErrorFormat
+	// ContentNegotiation sends error responses as XML, plain text or an HTML page when the
+	// Accept header prefers them, see Render
+	ContentNegotiation bool
+	// Logger logs every error response at LogLevelForStatus, slog.Default() when nil
+	Logger *slog.Logger
+	// RedactFields are the fields and query parameters whose values aren't logged or
+	// reported, DefaultRedactFields when nil
+	RedactFields []string
+	// Reporting forwards error responses to an error tracker
+	Reporting ReportingConfig
 }
 
 // CustomErrorHandler handles errors globally with improved context
 func CustomErrorHandler(err error, c echo.Context) {
-	var statusCode int
-	var message string
-
-	switch e := err.(type) {
-	case *echo.HTTPError:
-		statusCode = e.Code
-		message = fmt.Sprintf("%v", e.Message)
-	case *HTTPError:
-		statusCode = e.Code
-		message = e.Message
-	default:
-		statusCode = http.StatusInternalServerError
-		message = "An unexpected error occurred"
+	NewErrorHandler(ErrorHandlerConfig{})(err, c)
+}
+
+// NewErrorHandler creates an Echo error handler that renders errors as StandardErrorResponse
+func NewErrorHandler(config ErrorHandlerConfig) echo.HTTPErrorHandler {
+	reporting := newErrorReporting(config.Reporting)
+	return func(err error, c echo.Context) {
+		handleError(config, reporting, err, c)
 	}
+}
 
-	resp := NewStandardErrorResponse(statusCode)
-	errResp := resp.AddMessageError("error", message).JSON(c)
+func handleError(config ErrorHandlerConfig, reporting *errorReporting, err error, c echo.Context) {
+	// The handler maps errors like AddError, naming entries that aren't about a field "error"
+	resp := newFromError(err, "error").WithFormat(config.Format)
+
+	c.Set(ErrorCategoryKey, resp.Category())
+	config.logErrorResponse(c, err, resp)
+	resp.internalErr = nil // Logged with the response
+	reporting.report(c, err, resp, config.redactFields())
+	if c.Response().Committed || clientGone(c) {
+		// A partial response can't be replaced, and a gone client can't read one
+		return
+	}
+	sendErrorResponse(resp, c, config.ContentNegotiation)
+}
+
+// sendErrorResponse sends the response of the error handler, negotiating its format when
+// asked to, falling back to a minimal body when it can't be rendered
+func sendErrorResponse(resp *StandardErrorResponse, c echo.Context, negotiate bool) {
+	send := resp.JSON
+	if negotiate {
+		send = resp.Render
+	}
+	errResp := send(c)
 
 	if errResp != nil {
 		// Log the error and handle it
@@ -159,68 +361,7 @@ func CustomErrorHandler(err error, c echo.Context) {
 
 // getValidationErrorMessage returns human-friendly validation error messages
 func getValidationErrorMessage(validationErr validator.FieldError) string {
-	fieldName := humanizeFieldName(validationErr.Field())
-
-	switch validationErr.Tag() {
-	case "required":
-		return fmt.Sprintf("Please provide %s", fieldName)
-	case "email":
-		return fmt.Sprintf("Please enter a valid email address for %s", fieldName)
-	case "min":
-		return fmt.Sprintf("%s must be at least %s characters", fieldName, validationErr.Param())
-	case "max":
-		return fmt.Sprintf("%s cannot be longer than %s characters", fieldName, validationErr.Param())
-	case "gte":
-		return fmt.Sprintf("%s must be %s or greater", fieldName, validationErr.Param())
-	case "lte":
-		return fmt.Sprintf("%s must be %s or less", fieldName, validationErr.Param())
-	case "url":
-		return fmt.Sprintf("Please enter a valid URL for %s", fieldName)
-	case "datetime":
-		return fmt.Sprintf("Please enter a valid date and time for %s", fieldName)
-	default:
-		return fmt.Sprintf("%s has an invalid value", fieldName)
-	}
-}
-
-// isDatabaseError checks if the error is likely to originate from a database.
-func isDatabaseError(err error) bool {
-	return errors.Is(err, sql.ErrNoRows) || errors.Is(err, sql.ErrConnDone) ||
-		strings.Contains(err.Error(), "constraint") || strings.Contains(err.Error(), "syntax")
-}
-
-// getDatabaseErrorResponse maps database-related errors to appropriate HTTP status codes and messages.
-func getDatabaseErrorResponse(err error) (int, string) {
-	var statusCode int
-	var message string
-
-	if errors.Is(err, sql.ErrNoRows) {
-		statusCode = http.StatusNotFound
-		message = "We couldn't find what you're looking for"
-	} else if errors.Is(err, sql.ErrConnDone) {
-		statusCode = http.StatusInternalServerError
-		message = "We're having trouble connecting to our database. Please try again"
-	} else {
-		errMsg := err.Error()
-		switch {
-		case strings.Contains(errMsg, "unique constraint"):
-			statusCode = http.StatusConflict
-			message = "This information already exists in our system"
-		case strings.Contains(errMsg, "foreign key constraint"):
-			statusCode = http.StatusBadRequest
-			message = "This operation references invalid or non-existent data"
-		case strings.Contains(errMsg, "not-null constraint"):
-			statusCode = http.StatusBadRequest
-			message = "Required information is missing"
-		case strings.Contains(errMsg, "invalid input syntax"):
-			statusCode = http.StatusBadRequest
-			message = "The provided data format is invalid"
-		default:
-			statusCode = http.StatusInternalServerError
-			message = "An unexpected database error occurred"
-		}
-	}
-	return statusCode, message
+	return validationMessage(defaultLocale(), validationErr)
 }
 
 // humanizeFieldName converts camelCase field names to human-readable format
@@ -232,14 +373,21 @@ func humanizeFieldName(field string) string {
 	return strings.Join(words, " ")
 }
 
-// toSnakeCase converts camelCase to snake_case
+// toSnakeCase converts camelCase to snake_case, keeping acronyms together, e.g.
+// UserID to user_id and HTTPStatus to http_status
 func toSnakeCase(str string) string {
+	runes := []rune(str)
 	var result strings.Builder
-	for i, r := range str {
-		if i > 0 && r >= 'A' && r <= 'Z' {
+	for i, r := range runes {
+		if i > 0 && isUpper(r) && runes[i-1] != '_' &&
+			(!isUpper(runes[i-1]) || i+1 < len(runes) && runes[i+1] >= 'a' && runes[i+1] <= 'z') {
 			result.WriteRune('_')
 		}
 		result.WriteRune(r)
 	}
 	return strings.ToLower(result.String())
 }
+
+func isUpper(r rune) bool {
+	return r >= 'A' && r <= 'Z'
+}
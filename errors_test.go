@@ -124,7 +124,7 @@ func TestAddError(t *testing.T) {
 			name:            "GeneralError",
 			err:             errors.New("test error"),
 			expectedField:   "general",
-			expectedMessage: "test error",
+			expectedMessage: "An unexpected error occurred",
 		},
 	}
 
@@ -182,7 +182,7 @@ func TestGetValidationErrorMessage(t *testing.T) {
 	}
 }
 
-// TestGetDatabaseErrorMessage tests database error message generation
+// TestGetDatabaseErrorResponse tests the statuses and messages of typed driver errors
 func TestGetDatabaseErrorResponse(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -203,14 +203,26 @@ func TestGetDatabaseErrorResponse(t *testing.T) {
 			code:            http.StatusInternalServerError,
 		},
 		{
-			name:            "UniqueConstraint",
-			err:             errors.New("unique constraint violation"),
+			name:            "PostgresUniqueViolation",
+			err:             &pgxError{Code: "23505", Message: "duplicate key value violates unique constraint"},
 			expectedMessage: "This information already exists in our system",
 			code:            http.StatusConflict,
 		},
 		{
-			name:            "UnknownError",
-			err:             errors.New("unknown error"),
+			name:            "MySQLDuplicateEntry",
+			err:             &MySQLError{Number: 1062, Message: "Duplicate entry 'a@b.c' for key 'users.email'"},
+			expectedMessage: "This information already exists in our system",
+			code:            http.StatusConflict,
+		},
+		{
+			name:            "SQLiteUniqueConstraint",
+			err:             sqlite3Error{Code: 19, ExtendedCode: 2067, err: "UNIQUE constraint failed: users.email"},
+			expectedMessage: "This information already exists in our system",
+			code:            http.StatusConflict,
+		},
+		{
+			name:            "UnknownSQLState",
+			err:             &pqError{Code: "XX000", Message: "internal_error"},
 			expectedMessage: "An unexpected database error occurred",
 			code:            http.StatusInternalServerError,
 		},
@@ -218,13 +230,11 @@ func TestGetDatabaseErrorResponse(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			code, msg := getDatabaseErrorResponse(tt.err)
-
-			// Check that the status code matches the expected code
-			assert.Equal(t, tt.code, code)
-			// If no errors returned, ensure expectedMessage is empty
-			assert.Equal(t, tt.expectedMessage, msg)
+			dbErr, ok := ClassifyDatabaseError(tt.err)
 
+			assert.True(t, ok)
+			assert.Equal(t, tt.code, dbErr.Definition.Status)
+			assert.Equal(t, tt.expectedMessage, dbErr.Definition.Message)
 		})
 	}
 }
@@ -283,6 +293,16 @@ func TestToSnakeCase(t *testing.T) {
 			input:          "UserEmailAddress",
 			expectedOutput: "user_email_address",
 		},
+		{
+			name:           "TrailingAcronym",
+			input:          "ownerID",
+			expectedOutput: "owner_id",
+		},
+		{
+			name:           "LeadingAcronym",
+			input:          "HTTPStatus",
+			expectedOutput: "http_status",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,365 @@
+package goresponse
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ExportFormat is the file format of a streamed export
+type ExportFormat string
+
+const (
+	ExportCSV    ExportFormat = "csv"
+	ExportTSV    ExportFormat = "tsv"
+	ExportNDJSON ExportFormat = "ndjson"
+)
+
+// ExportOptions configures a streamed export
+type ExportOptions struct {
+	Format ExportFormat // Defaults to CSV
+	// Columns to export, named by csv tag, json tag or field name. Defaults to the
+	// filter's sparse fieldset and then to every exported field.
+	Columns []string
+	// Flush is called after every page has been written
+	Flush func()
+}
+
+type (
+	exportColumn struct {
+		name  string
+		index []int // struct field index, nil for map keys
+	}
+	exportEncoder interface {
+		Write(row reflect.Value) error
+		Flush() error
+	}
+	delimitedEncoder struct {
+		writeRecord func([]string) error
+		flush       func() error
+		columns     []exportColumn
+	}
+	ndjsonEncoder struct {
+		buffered *bufio.Writer
+		encoder  *json.Encoder
+		fields   []string
+	}
+	// lazyHeaderWriter commits the response headers on the first write, so errors of
+	// the first page can still be rendered as a normal error response
+	lazyHeaderWriter struct {
+		w       http.ResponseWriter
+		headers func(http.Header)
+		written bool
+	}
+)
+
+var tsvReplacer = strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+
+// ContentType returns the MIME type of the format
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case ExportTSV:
+		return "text/tab-separated-values; charset=utf-8"
+	case ExportNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// Extension returns the file extension of the format, including the dot
+func (f ExportFormat) Extension() string {
+	if f == "" {
+		return "." + string(ExportCSV)
+	}
+	return "." + string(f)
+}
+
+// Export walks every page from fetch, starting at filter, and streams the rows of
+// each page's Data, which must be a []T of structs or maps, to w. Only one page is
+// held in memory at a time.
+func Export[T any](ctx context.Context, w io.Writer, filter *FilterOptions, fetch PageFetcher, opts ExportOptions, pageOpts ...PageOption) error {
+	selected := opts.Columns
+	if len(selected) == 0 && filter != nil {
+		selected = filter.Fields
+	}
+	encoder, err := newExportEncoder(w, opts.Format, reflect.TypeFor[T](), selected)
+	if err != nil {
+		return err
+	}
+
+	for resp, err := range Pages(ctx, filter, fetch, pageOpts...) {
+		if err != nil {
+			return err
+		}
+		if err := writeExportPage[T](encoder, resp); err != nil {
+			return err
+		}
+		if opts.Flush != nil {
+			opts.Flush()
+		}
+	}
+	return encoder.Flush()
+}
+
+func writeExportPage[T any](encoder exportEncoder, resp *PaginatedResponse) error {
+	items, err := pageItems[T](resp)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := encoder.Write(reflect.ValueOf(&item).Elem()); err != nil {
+			return err
+		}
+	}
+	return encoder.Flush()
+}
+
+// ExportHTTP streams an export as a file download named filename, flushing the
+// response after every page
+func ExportHTTP[T any](w http.ResponseWriter, r *http.Request, filename string, filter *FilterOptions, fetch PageFetcher, opts ExportOptions, pageOpts ...PageOption) error {
+	if !strings.HasSuffix(filename, opts.Format.Extension()) {
+		filename += opts.Format.Extension()
+	}
+
+	writer := &lazyHeaderWriter{w: w, headers: func(h http.Header) {
+		h.Set("Content-Type", opts.Format.ContentType())
+		h.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+		h.Set("Cache-Control", "no-store")
+	}}
+
+	flush := opts.Flush
+	controller := http.NewResponseController(w)
+	opts.Flush = func() {
+		if writer.written {
+			_ = controller.Flush()
+		}
+		if flush != nil {
+			flush()
+		}
+	}
+	return Export[T](r.Context(), writer, filter, fetch, opts, pageOpts...)
+}
+
+// ExportEcho streams an export as a file download from an Echo handler
+func ExportEcho[T any](c echo.Context, filename string, filter *FilterOptions, fetch PageFetcher, opts ExportOptions, pageOpts ...PageOption) error {
+	return ExportHTTP[T](c.Response(), c.Request(), filename, filter, fetch, opts, pageOpts...)
+}
+
+func (lw *lazyHeaderWriter) Write(p []byte) (int, error) {
+	if !lw.written {
+		lw.headers(lw.w.Header())
+		lw.w.WriteHeader(http.StatusOK)
+		lw.written = true
+	}
+	return lw.w.Write(p)
+}
+
+func newExportEncoder(w io.Writer, format ExportFormat, rowType reflect.Type, selected []string) (exportEncoder, error) {
+	switch format {
+	case ExportNDJSON:
+		buffered := bufio.NewWriter(w)
+		return &ndjsonEncoder{buffered: buffered, encoder: json.NewEncoder(buffered), fields: selected}, nil
+	case ExportCSV, ExportTSV, "":
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+
+	columns, err := resolveExportColumns(rowType, selected)
+	if err != nil {
+		return nil, err
+	}
+	if format == ExportTSV {
+		buffered := bufio.NewWriter(w)
+		return newDelimitedEncoder(columns, tsvRecordWriter(buffered), buffered.Flush)
+	}
+
+	csvWriter := csv.NewWriter(w)
+	flush := func() error {
+		csvWriter.Flush()
+		return csvWriter.Error()
+	}
+	return newDelimitedEncoder(columns, csvWriter.Write, flush)
+}
+
+func tsvRecordWriter(w io.Writer) func([]string) error {
+	return func(record []string) error {
+		for i, value := range record {
+			record[i] = tsvReplacer.Replace(value)
+		}
+		_, err := io.WriteString(w, strings.Join(record, "\t")+"\n")
+		return err
+	}
+}
+
+func newDelimitedEncoder(columns []exportColumn, writeRecord func([]string) error, flush func() error) (exportEncoder, error) {
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.name
+	}
+	encoder := &delimitedEncoder{writeRecord: writeRecord, flush: flush, columns: columns}
+	return encoder, writeRecord(header)
+}
+
+func (e *delimitedEncoder) Write(row reflect.Value) error {
+	row = indirectValue(row)
+	record := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		value, err := formatExportValue(exportColumnValue(row, column))
+		if err != nil {
+			return fmt.Errorf("exporting column %s: %w", column.name, err)
+		}
+		record[i] = value
+	}
+	return e.writeRecord(record)
+}
+
+func (e *delimitedEncoder) Flush() error {
+	return e.flush()
+}
+
+func (e *ndjsonEncoder) Write(row reflect.Value) error {
+	if len(e.fields) == 0 {
+		return e.encoder.Encode(row.Interface())
+	}
+	projected, err := ProjectFields(row.Interface(), e.fields, nil)
+	if err != nil {
+		return err
+	}
+	return e.encoder.Encode(projected)
+}
+
+func (e *ndjsonEncoder) Flush() error {
+	return e.buffered.Flush()
+}
+
+// resolveExportColumns lists the columns of rowType, limited to and ordered by selected
+func resolveExportColumns(rowType reflect.Type, selected []string) ([]exportColumn, error) {
+	for rowType.Kind() == reflect.Ptr {
+		rowType = rowType.Elem()
+	}
+
+	if rowType.Kind() == reflect.Map {
+		if len(selected) == 0 {
+			return nil, fmt.Errorf("columns are required to export %s rows", rowType)
+		}
+		columns := make([]exportColumn, len(selected))
+		for i, name := range selected {
+			columns[i] = exportColumn{name: name}
+		}
+		return columns, nil
+	}
+	if rowType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot export rows of type %s", rowType)
+	}
+
+	available := structExportColumns(rowType)
+	if len(selected) == 0 {
+		return available, nil
+	}
+	return selectExportColumns(available, selected)
+}
+
+func structExportColumns(rowType reflect.Type) []exportColumn {
+	columns := make([]exportColumn, 0, rowType.NumField())
+	for _, field := range reflect.VisibleFields(rowType) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		if name := exportColumnName(field); name != "" {
+			columns = append(columns, exportColumn{name: name, index: field.Index})
+		}
+	}
+	return columns
+}
+
+// exportColumnName names a column by its csv tag, json tag or field name, "" skips it
+func exportColumnName(field reflect.StructField) string {
+	for _, tag := range []string{"csv", "json"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+func selectExportColumns(available []exportColumn, selected []string) ([]exportColumn, error) {
+	byName := make(map[string]exportColumn, len(available))
+	for _, column := range available {
+		byName[column.name] = column
+	}
+
+	columns := make([]exportColumn, len(selected))
+	for i, name := range selected {
+		column, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown export column %q", name)
+		}
+		columns[i] = column
+	}
+	return columns, nil
+}
+
+func exportColumnValue(row reflect.Value, column exportColumn) reflect.Value {
+	if !row.IsValid() {
+		return row
+	}
+	if column.index == nil {
+		return row.MapIndex(reflect.ValueOf(column.name).Convert(row.Type().Key()))
+	}
+	field, err := row.FieldByIndexErr(column.index)
+	if err != nil {
+		// Nil embedded pointer
+		return reflect.Value{}
+	}
+	return field
+}
+
+// indirectValue dereferences pointers and interfaces, nil yields an invalid Value
+func indirectValue(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func formatExportValue(v reflect.Value) (string, error) {
+	v = indirectValue(v)
+	if !v.IsValid() {
+		return "", nil
+	}
+
+	switch value := v.Interface().(type) {
+	case time.Time:
+		return value.Format(time.RFC3339), nil
+	case fmt.Stringer:
+		return value.String(), nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		raw, err := json.Marshal(v.Interface())
+		return string(raw), err
+	default:
+		return fmt.Sprint(v.Interface()), nil
+	}
+}
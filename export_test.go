@@ -0,0 +1,136 @@
+package goresponse
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type exportRow struct {
+	ID        uuid.UUID         `json:"id"`
+	Name      string            `json:"name" csv:"full_name"`
+	Score     *float64          `json:"score"`
+	Tags      []string          `json:"tags"`
+	CreatedAt time.Time         `json:"created_at"`
+	Secret    string            `json:"-"`
+	Meta      map[string]string `json:"meta,omitempty" csv:"-"`
+}
+
+func exportFetcher(rows []exportRow) PageFetcher {
+	return func(_ context.Context, filter *FilterOptions) (*PaginatedResponse, error) {
+		start := min(*filter.Offset, len(rows))
+		end := min(start+filter.Limit, len(rows))
+		return GeneratePaginatedResponse(rows[start:end], len(rows), filter), nil
+	}
+}
+
+func testExportRows() []exportRow {
+	score := 9.5
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	return []exportRow{
+		{ID: uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"), Name: "Jane, \"JD\" Doe", Score: &score, Tags: []string{"a", "b"}, CreatedAt: createdAt, Secret: "x"},
+		{ID: uuid.MustParse("987fcdeb-51a2-43d7-9012-345678901234"), Name: "John\tSmith", CreatedAt: createdAt},
+		{ID: uuid.Nil, Name: "Eve", CreatedAt: createdAt},
+	}
+}
+
+func TestExport(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *FilterOptions
+		opts   ExportOptions
+		want   string
+	}{
+		{
+			name:   "csv with every column",
+			filter: &FilterOptions{Limit: 2},
+			opts:   ExportOptions{Format: ExportCSV},
+			want: "id,full_name,score,tags,created_at\n" +
+				"123e4567-e89b-12d3-a456-426614174000,\"Jane, \"\"JD\"\" Doe\",9.5,\"[\"\"a\"\",\"\"b\"\"]\",2024-01-02T03:04:05Z\n" +
+				"987fcdeb-51a2-43d7-9012-345678901234,John\tSmith,,null,2024-01-02T03:04:05Z\n" +
+				"00000000-0000-0000-0000-000000000000,Eve,,null,2024-01-02T03:04:05Z\n",
+		},
+		{
+			name:   "tsv with columns from the sparse fieldset",
+			filter: &FilterOptions{Limit: 2, Fields: []string{"full_name", "score"}},
+			opts:   ExportOptions{Format: ExportTSV},
+			want:   "full_name\tscore\nJane, \"JD\" Doe\t9.5\nJohn Smith\t\nEve\t\n",
+		},
+		{
+			name:   "ndjson with selected columns",
+			filter: &FilterOptions{Limit: 10},
+			opts:   ExportOptions{Format: ExportNDJSON, Columns: []string{"name"}},
+			want:   "{\"name\":\"Jane, \\\"JD\\\" Doe\"}\n{\"name\":\"John\\tSmith\"}\n{\"name\":\"Eve\"}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			flushes := 0
+			tt.opts.Flush = func() { flushes++ }
+
+			err := Export[exportRow](context.Background(), &buf, tt.filter, exportFetcher(testExportRows()), tt.opts)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+			assert.Equal(t, (3+tt.filter.Limit-1)/tt.filter.Limit, flushes, "Flush should be called once per page")
+		})
+	}
+}
+
+func TestExportMapsAndErrors(t *testing.T) {
+	fetch := func(_ context.Context, filter *FilterOptions) (*PaginatedResponse, error) {
+		return GeneratePaginatedResponse([]map[string]interface{}{{"id": 1, "name": "ann"}}, 1, filter), nil
+	}
+
+	var buf bytes.Buffer
+	err := Export[map[string]interface{}](context.Background(), &buf, &FilterOptions{Limit: 10}, fetch, ExportOptions{Columns: []string{"name", "id", "missing"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "name,id,missing\nann,1,\n", buf.String())
+
+	err = Export[map[string]interface{}](context.Background(), &buf, &FilterOptions{Limit: 10}, fetch, ExportOptions{})
+	assert.EqualError(t, err, "columns are required to export map[string]interface {} rows")
+
+	err = Export[exportRow](context.Background(), &buf, &FilterOptions{Limit: 10}, exportFetcher(nil), ExportOptions{Columns: []string{"secret"}})
+	assert.EqualError(t, err, `unknown export column "secret"`)
+
+	err = Export[exportRow](context.Background(), &buf, &FilterOptions{Limit: 10}, exportFetcher(nil), ExportOptions{Format: "xlsx"})
+	assert.EqualError(t, err, `unsupported export format "xlsx"`)
+}
+
+func TestExportHTTP(t *testing.T) {
+	t.Run("streams a download", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/export", nil)
+		rec := httptest.NewRecorder()
+
+		err := ExportHTTP[exportRow](rec, req, "users", &FilterOptions{Limit: 2}, exportFetcher(testExportRows()), ExportOptions{Format: ExportTSV})
+		assert.NoError(t, err)
+		assert.Equal(t, "text/tab-separated-values; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename=users.tsv`, rec.Header().Get("Content-Disposition"))
+		assert.True(t, rec.Flushed)
+		assert.Contains(t, rec.Body.String(), "Eve")
+	})
+
+	t.Run("first page error leaves the response untouched", func(t *testing.T) {
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/export", nil), rec)
+		fetch := func(context.Context, *FilterOptions) (*PaginatedResponse, error) {
+			return nil, errors.New("database is down")
+		}
+
+		err := ExportEcho[exportRow](c, "users.csv", &FilterOptions{Limit: 2}, fetch, ExportOptions{})
+		assert.EqualError(t, err, "database is down")
+		assert.False(t, c.Response().Committed)
+		assert.Empty(t, rec.Header().Get("Content-Disposition"))
+		assert.Empty(t, rec.Body.String())
+	})
+}
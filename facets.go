@@ -0,0 +1,111 @@
+package goresponse
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+	"time"
+)
+
+// FacetType identifies the kind of buckets in a Facet
+type FacetType string
+
+const (
+	FacetTerms         FacetType = "terms"
+	FacetDateHistogram FacetType = "date_histogram"
+	FacetRange         FacetType = "range"
+)
+
+type (
+	// Facet is an aggregation shown next to the results, e.g. counts per status
+	Facet struct {
+		Type     FacetType     `json:"type"`
+		Interval string        `json:"interval,omitempty"` // Date histogram interval, e.g. "month"
+		Buckets  []FacetBucket `json:"buckets"`
+	}
+	// FacetBucket is a single bucket of a Facet. Range buckets set From/To and date
+	// histogram buckets set Start/End.
+	FacetBucket struct {
+		Key   string     `json:"key"`
+		Count int        `json:"count"`
+		From  *float64   `json:"from,omitempty"`
+		To    *float64   `json:"to,omitempty"`
+		Start *time.Time `json:"start,omitempty"`
+		End   *time.Time `json:"end,omitempty"`
+	}
+)
+
+// WithAllowedFacets rejects facets params that request facets outside of names
+func WithAllowedFacets(names ...string) ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.postParse = append(cfg.postParse, func(filter *FilterOptions, _ url.Values) error {
+			for _, facet := range filter.Facets {
+				if !slices.Contains(names, facet) {
+					return &QueryParamError{Param: "facets", Message: fmt.Sprintf("Unknown facet %q", facet)}
+				}
+			}
+			return nil
+		})
+	}
+}
+
+// WantsFacet reports whether the facet was requested with the facets param
+func (f *FilterOptions) WantsFacet(name string) bool {
+	return slices.Contains(f.Facets, name)
+}
+
+// TermBucket creates a bucket counting a single value
+func TermBucket(value string, count int) FacetBucket {
+	return FacetBucket{Key: value, Count: count}
+}
+
+// RangeBucket creates a bucket counting values from (inclusive) up to to (exclusive),
+// a nil bound leaves that side open
+func RangeBucket(key string, from, to *float64, count int) FacetBucket {
+	return FacetBucket{Key: key, Count: count, From: from, To: to}
+}
+
+// DateBucket creates a date histogram bucket counting values from start up to end
+func DateBucket(start, end time.Time, count int) FacetBucket {
+	return FacetBucket{Key: start.Format(time.RFC3339), Count: count, Start: &start, End: &end}
+}
+
+// NewTermsFacet creates a term counts facet
+func NewTermsFacet(buckets ...FacetBucket) *Facet {
+	return &Facet{Type: FacetTerms, Buckets: buckets}
+}
+
+// TermsFacetFromCounts creates a term counts facet ordered by count, then value
+func TermsFacetFromCounts(counts map[string]int) *Facet {
+	buckets := make([]FacetBucket, 0, len(counts))
+	for value, count := range counts {
+		buckets = append(buckets, TermBucket(value, count))
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		if buckets[i].Count != buckets[j].Count {
+			return buckets[i].Count > buckets[j].Count
+		}
+		return buckets[i].Key < buckets[j].Key
+	})
+	return NewTermsFacet(buckets...)
+}
+
+// NewRangeFacet creates a numeric ranges facet
+func NewRangeFacet(buckets ...FacetBucket) *Facet {
+	return &Facet{Type: FacetRange, Buckets: buckets}
+}
+
+// NewDateHistogramFacet creates a date histogram facet with the given interval
+func NewDateHistogramFacet(interval string, buckets ...FacetBucket) *Facet {
+	return &Facet{Type: FacetDateHistogram, Interval: interval, Buckets: buckets}
+}
+
+// AddFacet adds a facet to the response under name
+func (r *PaginatedResponse) AddFacet(name string, facet *Facet) *PaginatedResponse {
+	if r.Facets == nil {
+		r.Facets = make(map[string]*Facet)
+	}
+	r.Facets[name] = facet
+	return r
+}
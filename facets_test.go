@@ -0,0 +1,62 @@
+package goresponse
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseURLValuesFacets(t *testing.T) {
+	filter, err := ParseURLValues(url.Values{"facets": []string{"status, type"}}, WithAllowedFacets("status", "type"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"status", "type"}, filter.Facets)
+	assert.True(t, filter.WantsFacet("status"))
+	assert.False(t, filter.WantsFacet("category"))
+
+	_, err = ParseURLValues(url.Values{"facets": []string{"status,owner"}}, WithAllowedFacets("status"))
+	response := NewStandardErrorResponse(http.StatusBadRequest).AddError(err)
+	assert.Equal(t, "facets", response.Errors[0]["field"])
+	assert.Equal(t, `Unknown facet "owner"`, response.Errors[0]["message"])
+}
+
+func TestGenerateCacheKeyFacets(t *testing.T) {
+	base := FilterOptions{Page: 1, Limit: 10}
+	withFacets := FilterOptions{Page: 1, Limit: 10, Facets: []string{"status"}}
+	reordered := FilterOptions{Page: 1, Limit: 10, Facets: []string{"type", "status"}}
+	sameSet := FilterOptions{Page: 1, Limit: 10, Facets: []string{"status", "type"}}
+
+	assert.NotEqual(t, base.GenerateCacheKey("test:"), withFacets.GenerateCacheKey("test:"))
+	assert.Equal(t, reordered.GenerateCacheKey("test:"), sameSet.GenerateCacheKey("test:"))
+}
+
+func TestPaginatedResponseFacets(t *testing.T) {
+	low, high := 0.0, 100.0
+	january := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	february := january.AddDate(0, 1, 0)
+
+	response := GeneratePaginatedResponse([]int{1}, 1, &FilterOptions{Page: 1, Limit: 10}).
+		AddFacet("status", TermsFacetFromCounts(map[string]int{"draft": 2, "published": 5, "archived": 2})).
+		AddFacet("price", NewRangeFacet(RangeBucket("cheap", &low, &high, 3), RangeBucket("expensive", &high, nil, 1))).
+		AddFacet("created_at", NewDateHistogramFacet("month", DateBucket(january, february, 4)))
+
+	raw, err := json.Marshal(response.Facets)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"status": {"type": "terms", "buckets": [
+			{"key": "published", "count": 5},
+			{"key": "archived", "count": 2},
+			{"key": "draft", "count": 2}
+		]},
+		"price": {"type": "range", "buckets": [
+			{"key": "cheap", "count": 3, "from": 0, "to": 100},
+			{"key": "expensive", "count": 1, "from": 100}
+		]},
+		"created_at": {"type": "date_histogram", "interval": "month", "buckets": [
+			{"key": "2024-01-01T00:00:00Z", "count": 4, "start": "2024-01-01T00:00:00Z", "end": "2024-02-01T00:00:00Z"}
+		]}
+	}`, string(raw))
+}
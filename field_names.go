@@ -0,0 +1,102 @@
+package goresponse
+
+import (
+	"reflect"
+	"strings"
+	"sync/atomic"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldNameStrategy selects how the field of a validation error is named
+type FieldNameStrategy int32
+
+const (
+	// FieldNamePath names fields by their full path, e.g. items[2].unit_price. Fields
+	// named by the validator's tag name func, see UseJSONFieldNames, keep that name and
+	// the others are snake_cased.
+	FieldNamePath FieldNameStrategy = iota
+	// FieldNameSnakeCase names fields by their snake_cased name without the path, e.g.
+	// unit_price, also when the validator names them after json tags
+	FieldNameSnakeCase
+)
+
+var fieldNameStrategy atomic.Int32
+
+// SetFieldNameStrategy sets how the fields of validation errors are named,
+// FieldNamePath by default
+func SetFieldNameStrategy(strategy FieldNameStrategy) {
+	fieldNameStrategy.Store(int32(strategy))
+}
+
+// UseJSONFieldNames makes the validator name fields after their json tag, so validation
+// errors are reported with the keys clients sent
+func UseJSONFieldNames(v *validator.Validate) {
+	v.RegisterTagNameFunc(jsonFieldName)
+}
+
+// jsonFieldName returns the json key of a struct field, empty to keep the Go name
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// validationFieldName names the field of a validation error after the strategy
+func validationFieldName(validationErr validator.FieldError) string {
+	if FieldNameStrategy(fieldNameStrategy.Load()) == FieldNameSnakeCase {
+		return snakeCaseSegment(validationErr.Field())
+	}
+
+	names := splitNamespace(validationErr.Namespace())
+	structNames := splitNamespace(validationErr.StructNamespace())
+	if len(names) > 1 {
+		// Leave out the name of the validated struct
+		names, structNames = names[1:], structNames[1:]
+	}
+	for i, name := range names {
+		if i < len(structNames) {
+			names[i] = segmentName(name, structNames[i])
+		}
+	}
+	return strings.Join(names, ".")
+}
+
+// splitNamespace splits a validator namespace on the dots outside of brackets, e.g.
+// Order.Items[2].Attrs[a.b] into Order, Items[2] and Attrs[a.b]
+func splitNamespace(namespace string) []string {
+	var segments []string
+	depth, start := 0, 0
+	for i, r := range namespace {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		case r == '.' && depth == 0:
+			segments = append(segments, namespace[start:i])
+			start = i + 1
+		}
+	}
+	return append(segments, namespace[start:])
+}
+
+// segmentName keeps a name given by the validator's tag name func, e.g. a json tag used
+// verbatim, and snake_cases a Go field name
+func segmentName(name, structName string) string {
+	if name != structName {
+		return name
+	}
+	return snakeCaseSegment(name)
+}
+
+// snakeCaseSegment snake_cases the name of a namespace segment, keeping its indices
+func snakeCaseSegment(segment string) string {
+	name, indices, found := strings.Cut(segment, "[")
+	if !found {
+		return toSnakeCase(name)
+	}
+	return toSnakeCase(name) + "[" + indices
+}
@@ -0,0 +1,94 @@
+package goresponse
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	orderLine struct {
+		UnitPrice int    `json:"unitPrice" validate:"gt=0"`
+		Sku       string `validate:"required"`
+	}
+	orderCustomer struct {
+		EmailAddress string `json:"email" validate:"email"`
+		Owner        string `json:"ownerID" validate:"required"`
+	}
+	orderForm struct {
+		Lines    []orderLine   `json:"line_items" validate:"dive"`
+		Customer orderCustomer `json:"customer"`
+		Note     string        `json:"-" validate:"max=3"`
+	}
+)
+
+var invalidOrderForm = orderForm{
+	Lines:    []orderLine{{UnitPrice: 10, Sku: "A-1"}, {UnitPrice: 0}},
+	Customer: orderCustomer{EmailAddress: "not-an-email"},
+	Note:     "too long",
+}
+
+func TestValidationFieldNames(t *testing.T) {
+	jsonValidator := validator.New()
+	UseJSONFieldNames(jsonValidator)
+
+	tests := []struct {
+		name     string
+		validate *validator.Validate
+		strategy FieldNameStrategy
+		want     []string
+	}{
+		{
+			name:     "go names as snake case paths",
+			validate: validator.New(),
+			strategy: FieldNamePath,
+			want:     []string{"lines[1].unit_price", "lines[1].sku", "customer.email_address", "customer.owner", "note"},
+		},
+		{
+			name:     "json names",
+			validate: jsonValidator,
+			strategy: FieldNamePath,
+			want:     []string{"line_items[1].unitPrice", "line_items[1].sku", "customer.email", "customer.ownerID", "note"},
+		},
+		{
+			name:     "snake case strategy",
+			validate: jsonValidator,
+			strategy: FieldNameSnakeCase,
+			want:     []string{"unit_price", "sku", "email", "owner_id", "note"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetFieldNameStrategy(tt.strategy)
+			t.Cleanup(func() { SetFieldNameStrategy(FieldNamePath) })
+
+			response := NewStandardErrorResponse(http.StatusUnprocessableEntity).AddError(tt.validate.Struct(invalidOrderForm))
+			fields := make([]string, len(response.Errors))
+			for i, entry := range response.Errors {
+				fields[i] = entry["field"]
+			}
+			assert.Equal(t, tt.want, fields)
+		})
+	}
+}
+
+func TestSplitNamespace(t *testing.T) {
+	tests := []struct {
+		namespace string
+		want      []string
+	}{
+		{namespace: "Email", want: []string{"Email"}},
+		{namespace: "Order.Items[2].Price", want: []string{"Order", "Items[2]", "Price"}},
+		{namespace: "Order.Attrs[a.b].Value", want: []string{"Order", "Attrs[a.b]", "Value"}},
+		{namespace: "Order.Matrix[1][2]", want: []string{"Order", "Matrix[1][2]"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.namespace, func(t *testing.T) {
+			assert.Equal(t, tt.want, splitNamespace(tt.namespace))
+		})
+	}
+}
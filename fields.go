@@ -0,0 +1,202 @@
+package goresponse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// WithAllowedFields restricts the sparse fieldset of a resource to the given JSON fields.
+// Use an empty resource for the top-level fields param and the relation name for
+// fields[resource]. Once any allowlist is set, fieldsets of other resources are rejected.
+func WithAllowedFields(resource string, fields ...string) ParseOption {
+	return func(cfg *parseConfig) {
+		if cfg.allowedFields == nil {
+			cfg.allowedFields = make(map[string]map[string]struct{})
+			cfg.postParse = append(cfg.postParse, func(filter *FilterOptions, _ url.Values) error {
+				return validateFieldsets(filter, cfg.allowedFields)
+			})
+		}
+		if cfg.allowedFields[resource] == nil {
+			cfg.allowedFields[resource] = make(map[string]struct{}, len(fields))
+		}
+		for _, field := range fields {
+			cfg.allowedFields[resource][field] = struct{}{}
+		}
+	}
+}
+
+// fieldsParam returns the query param name of a resource's sparse fieldset
+func fieldsParam(resource string) string {
+	if resource == "" {
+		return "fields"
+	}
+	return "fields[" + resource + "]"
+}
+
+// handleResourceFields parses fields[resource]=a,b params and reports whether param was one
+func handleResourceFields(filter *FilterOptions, param string, values []string) bool {
+	if !strings.HasPrefix(param, "fields[") || !strings.HasSuffix(param, "]") {
+		return false
+	}
+	resource := strings.TrimSpace(param[len("fields[") : len(param)-1])
+	if resource == "" || strings.ContainsAny(resource, "[]") {
+		return false
+	}
+
+	fields := cleanStringSlice(strings.Split(values[0], ","))
+	if filter.ResourceFields == nil {
+		filter.ResourceFields = make(map[string][]string)
+	}
+	filter.ResourceFields[resource] = fields
+	return true
+}
+
+func validateFieldsets(filter *FilterOptions, allowed map[string]map[string]struct{}) error {
+	if err := validateFieldset("", filter.Fields, allowed); err != nil {
+		return err
+	}
+	for resource, fields := range filter.ResourceFields {
+		if err := validateFieldset(resource, fields, allowed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateFieldset(resource string, fields []string, allowed map[string]map[string]struct{}) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	allowedFields, ok := allowed[resource]
+	if !ok {
+		return &QueryParamError{Param: fieldsParam(resource), Message: fmt.Sprintf("Fields can't be selected for %q", resource)}
+	}
+	for _, field := range fields {
+		if _, ok := allowedFields[field]; !ok {
+			return &QueryParamError{Param: fieldsParam(resource), Message: fmt.Sprintf("Unknown field %q", field)}
+		}
+	}
+	return nil
+}
+
+// resourceFieldValues encodes ResourceFields as sorted fields[resource] query params
+func (f FilterOptions) resourceFieldValues() url.Values {
+	values := url.Values{}
+	for resource, fields := range f.ResourceFields {
+		if len(fields) == 0 {
+			continue
+		}
+		sorted := append([]string(nil), fields...)
+		sort.Strings(sorted)
+		values.Set(fieldsParam(resource), strings.Join(sorted, ","))
+	}
+	return values
+}
+
+// HasFieldset reports whether any sparse fieldset was requested
+func (f *FilterOptions) HasFieldset() bool {
+	if f == nil {
+		return false
+	}
+	if len(f.Fields) > 0 {
+		return true
+	}
+	for _, fields := range f.ResourceFields {
+		if len(fields) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectFields reduces data, a struct, map or slice of them, to the given JSON fields.
+// nested holds the fields kept for objects found under a key, e.g. {"author": {"id"}}.
+// The result is the generic JSON form of data, ready to be marshaled.
+func ProjectFields(data interface{}, fields []string, nested map[string][]string) (interface{}, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return projectValue(generic, fields, nested), nil
+}
+
+func projectValue(value interface{}, fields []string, nested map[string][]string) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		for i, item := range v {
+			v[i] = projectValue(item, fields, nested)
+		}
+		return v
+	case map[string]interface{}:
+		return projectObject(v, fields, nested)
+	default:
+		return v
+	}
+}
+
+func projectObject(object map[string]interface{}, fields []string, nested map[string][]string) map[string]interface{} {
+	if len(fields) > 0 {
+		keep := make(map[string]struct{}, len(fields))
+		for _, field := range fields {
+			keep[field] = struct{}{}
+		}
+		for key := range object {
+			if _, ok := keep[key]; !ok {
+				delete(object, key)
+			}
+		}
+	}
+
+	for key, value := range object {
+		if nestedFields, ok := nested[key]; ok {
+			object[key] = projectValue(value, nestedFields, nested)
+		}
+	}
+	return object
+}
+
+// projectData projects data to the sparse fieldsets of filter, data is kept as it is
+// without a fieldset
+func projectData(data interface{}, filter *FilterOptions) (interface{}, error) {
+	if !filter.HasFieldset() {
+		return data, nil
+	}
+	return ProjectFields(data, filter.Fields, filter.ResourceFields)
+}
+
+type (
+	paginatedJSON  PaginatedResponse
+	singleDataJSON SingleDataResponse
+)
+
+// MarshalJSON renders the page with Data projected to the requested sparse fieldsets
+func (r PaginatedResponse) MarshalJSON() ([]byte, error) {
+	data, err := projectData(r.Data, r.fieldset)
+	if err != nil {
+		return nil, err
+	}
+	r.Data = data
+	return json.Marshal(paginatedJSON(r))
+}
+
+// MarshalJSON renders the response with Data projected to the sparse fieldsets selected
+// with SelectFields
+func (r SingleDataResponse) MarshalJSON() ([]byte, error) {
+	data, err := projectData(r.Data, r.fieldset)
+	if err != nil {
+		return nil, err
+	}
+	r.Data = data
+	return json.Marshal(singleDataJSON(r))
+}
@@ -0,0 +1,158 @@
+package goresponse
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testAuthor struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+type testArticle struct {
+	ID     int         `json:"id"`
+	Title  string      `json:"title"`
+	Body   string      `json:"body"`
+	Author *testAuthor `json:"author"`
+}
+
+func TestParseURLValuesFields(t *testing.T) {
+	tests := []struct {
+		name           string
+		urlValues      url.Values
+		wantFields     []string
+		wantResources  map[string][]string
+		wantErr        bool
+		wantErrField   string
+		wantErrMessage string
+	}{
+		{
+			name: "fields and nested resource fields",
+			urlValues: url.Values{
+				"fields":         []string{"id, title,,author"},
+				"fields[author]": []string{"name"},
+			},
+			wantFields:    []string{"id", "title", "author"},
+			wantResources: map[string][]string{"author": {"name"}},
+		},
+		{
+			name:           "field outside the allowlist",
+			urlValues:      url.Values{"fields": []string{"id,password"}},
+			wantErr:        true,
+			wantErrField:   "fields",
+			wantErrMessage: `Unknown field "password"`,
+		},
+		{
+			name:           "resource without an allowlist",
+			urlValues:      url.Values{"fields[comments]": []string{"id"}},
+			wantErr:        true,
+			wantErrField:   "fields[comments]",
+			wantErrMessage: `Fields can't be selected for "comments"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseURLValues(tt.urlValues,
+				WithAllowedFields("", "id", "title", "author"),
+				WithAllowedFields("author", "id", "name"),
+			)
+
+			if tt.wantErr {
+				response := NewStandardErrorResponse(http.StatusBadRequest).AddError(err)
+				assert.Equal(t, tt.wantErrField, response.Errors[0]["field"])
+				assert.Equal(t, tt.wantErrMessage, response.Errors[0]["message"])
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantFields, got.Fields)
+			assert.Equal(t, tt.wantResources, got.ResourceFields)
+			assert.Empty(t, got.DynamicFields)
+		})
+	}
+}
+
+func TestGenerateCacheKeyFields(t *testing.T) {
+	base := FilterOptions{Page: 1, Limit: 10}
+	withFields := FilterOptions{Page: 1, Limit: 10, Fields: []string{"id"}}
+	withResourceFields := FilterOptions{Page: 1, Limit: 10, ResourceFields: map[string][]string{"author": {"id"}}}
+
+	assert.NotEqual(t, base.GenerateCacheKey("test:"), withFields.GenerateCacheKey("test:"))
+	assert.NotEqual(t, base.GenerateCacheKey("test:"), withResourceFields.GenerateCacheKey("test:"))
+	assert.NotEqual(t, withFields.GenerateCacheKey("test:"), withResourceFields.GenerateCacheKey("test:"))
+	assert.Equal(t, "id", withResourceFields.URLValues().Get("fields[author]"))
+}
+
+func TestProjectFields(t *testing.T) {
+	article := testArticle{
+		ID:     1,
+		Title:  "Hello",
+		Body:   "Long body",
+		Author: &testAuthor{ID: 2, Name: "Jane", Email: "jane@example.com"},
+	}
+
+	got, err := ProjectFields([]testArticle{article}, []string{"id", "author"}, map[string][]string{"author": {"name"}})
+	assert.NoError(t, err)
+
+	raw, err := json.Marshal(got)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"id":1,"author":{"name":"Jane"}}]`, string(raw))
+
+	_, err = ProjectFields(make(chan int), []string{"id"}, nil)
+	assert.Error(t, err)
+}
+
+func TestResponsesSelectFields(t *testing.T) {
+	article := testArticle{ID: 1, Title: "Hello", Body: "Long body"}
+	filter := &FilterOptions{Page: 1, Limit: 10, Fields: []string{"title"}}
+
+	paginated := GeneratePaginatedResponse([]testArticle{article}, 1, filter)
+	assert.Equal(t, []testArticle{article}, paginated.Data, "Data stays typed for in-process consumers")
+	raw, err := json.Marshal(paginated)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"title":"Hello"}]`, string(jsonMember(t, raw, "data")))
+
+	single := GenerateSingleDataResponse(article, "", 0).SelectFields(filter)
+	assert.Equal(t, article, single.Data)
+	raw, err = json.Marshal(single)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"title":"Hello"}`, string(jsonMember(t, raw, "data")))
+
+	raw, err = json.Marshal(GenerateSingleDataResponse(article, "", 0).SelectFields(&FilterOptions{}))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id":1,"title":"Hello","body":"Long body","author":null}`, string(jsonMember(t, raw, "data")))
+
+	// Data that can't be projected fails instead of leaking unselected fields
+	_, err = json.Marshal(GeneratePaginatedResponse(make(chan int), 1, filter))
+	assert.Error(t, err)
+}
+
+func TestAllPagesWithFieldset(t *testing.T) {
+	filter := &FilterOptions{Page: 1, Limit: 10, Fields: []string{"id"}}
+	fetch := func(_ context.Context, f *FilterOptions) (*PaginatedResponse, error) {
+		return GeneratePaginatedResponse([]testArticle{{ID: 1}, {ID: 2}}, 2, f), nil
+	}
+
+	var ids []int
+	for article, err := range AllPages[testArticle](context.Background(), filter, fetch) {
+		assert.NoError(t, err)
+		ids = append(ids, article.ID)
+	}
+	assert.Equal(t, []int{1, 2}, ids)
+}
+
+// jsonMember returns a member of a marshaled JSON object
+func jsonMember(t *testing.T, raw []byte, name string) json.RawMessage {
+	t.Helper()
+	var members map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal(raw, &members))
+	return members[name]
+}
@@ -0,0 +1,126 @@
+package goresponse
+
+import (
+	"bytes"
+	"html/template"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ResponsePage is what the plain text and HTML formats show of a response, see
+// SetErrorPageTemplate and SetDataPageTemplate
+type ResponsePage struct {
+	Status      int
+	Title       string              // Text of Status, e.g. Not Found
+	Message     string              // Message of the response
+	Errors      []map[string]string // Entries of an error response
+	Data        string              // Data of a successful response as indented JSON
+	CurrentPage int                 // Pagination of a PaginatedResponse
+	TotalPage   int
+	TotalData   int
+	RequestID   string
+	ReferenceID string
+	Lang        string      // Locale of the messages
+	Response    interface{} // The rendered envelope
+}
+
+const defaultErrorPage = `<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<title>{{.Status}} {{.Title}}</title>
+</head>
+<body>
+<h1>{{.Status}} {{.Title}}</h1>
+<p>{{.Message}}</p>
+{{- if .Errors}}
+<ul>
+{{- range .Errors}}
+<li><strong>{{index . "field"}}</strong>: {{index . "message"}} <code>{{index . "code"}}</code></li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .RequestID}}
+<p><small>Request ID: {{.RequestID}}</small></p>
+{{- end}}
+{{- if .ReferenceID}}
+<p><small>Reference ID: {{.ReferenceID}}</small></p>
+{{- end}}
+</body>
+</html>
+`
+
+const defaultDataPage = `<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<title>{{.Status}} {{.Title}}</title>
+</head>
+<body>
+{{- if .Message}}
+<h1>{{.Message}}</h1>
+{{- end}}
+{{- if .TotalPage}}
+<p>Page {{.CurrentPage}} of {{.TotalPage}}, {{.TotalData}} in total</p>
+{{- end}}
+<pre>{{.Data}}</pre>
+{{- if .RequestID}}
+<p><small>Request ID: {{.RequestID}}</small></p>
+{{- end}}
+</body>
+</html>
+`
+
+// htmlTemplates holds the templates of the HTML format
+var htmlTemplates = struct {
+	sync.RWMutex
+	errorPage *template.Template
+	dataPage  *template.Template
+}{
+	errorPage: template.Must(template.New("error").Parse(defaultErrorPage)),
+	dataPage:  template.Must(template.New("data").Parse(defaultDataPage)),
+}
+
+// SetErrorPageTemplate replaces the HTML page of error responses, executed with a
+// *ResponsePage. A nil template restores the default page.
+func SetErrorPageTemplate(tmpl *template.Template) {
+	if tmpl == nil {
+		tmpl = template.Must(template.New("error").Parse(defaultErrorPage))
+	}
+	htmlTemplates.Lock()
+	defer htmlTemplates.Unlock()
+	htmlTemplates.errorPage = tmpl
+}
+
+// SetDataPageTemplate replaces the HTML page of successful responses, executed with a
+// *ResponsePage. A nil template restores the default page.
+func SetDataPageTemplate(tmpl *template.Template) {
+	if tmpl == nil {
+		tmpl = template.Must(template.New("data").Parse(defaultDataPage))
+	}
+	htmlTemplates.Lock()
+	defer htmlTemplates.Unlock()
+	htmlTemplates.dataPage = tmpl
+}
+
+func errorPageTemplate() *template.Template {
+	htmlTemplates.RLock()
+	defer htmlTemplates.RUnlock()
+	return htmlTemplates.errorPage
+}
+
+func dataPageTemplate() *template.Template {
+	htmlTemplates.RLock()
+	defer htmlTemplates.RUnlock()
+	return htmlTemplates.dataPage
+}
+
+// renderHTML sends page rendered with tmpl, nothing is sent when the template fails
+func renderHTML(c echo.Context, page *ResponsePage, tmpl *template.Template) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, page); err != nil {
+		return err
+	}
+	return c.HTMLBlob(page.Status, buf.Bytes())
+}
@@ -0,0 +1,52 @@
+package goresponse
+
+// NotFound reports that the resource with the given id doesn't exist, e.g.
+// NotFound("user", 42). A nil id leaves it out of the message.
+func NotFound(resource string, id interface{}) *HTTPError {
+	if id == nil {
+		return errDefNotFound.Newf("We couldn't find the %s", resource)
+	}
+	return errDefNotFound.Newf("We couldn't find %s %v", resource, id)
+}
+
+// Conflict reports that the request conflicts with the current state of a resource
+func Conflict(message string) *HTTPError {
+	return raise(errDefConflict, message)
+}
+
+// Unauthorized reports that the request lacks valid credentials
+func Unauthorized(message string) *HTTPError {
+	return raise(errDefUnauthorized, message)
+}
+
+// Forbidden reports that the credentials don't allow the request
+func Forbidden(message string) *HTTPError {
+	return raise(errDefForbidden, message)
+}
+
+// Validation reports invalid fields, each one becoming an entry of the response
+func Validation(fields ...FieldDetail) *HTTPError {
+	err := errDefValidation.New()
+	err.Fields = fields
+	return err
+}
+
+// WithField adds a field detail coded after the error
+func (e *HTTPError) WithField(field, message string) *HTTPError {
+	e.Fields = append(e.Fields, FieldDetail{Field: field, Message: message})
+	return e
+}
+
+// Wrap keeps err as the internal cause, it's never shown to clients
+func (e *HTTPError) Wrap(err error) *HTTPError {
+	e.Internal = err
+	return e
+}
+
+// raise raises def with message, or its localized default message when empty
+func raise(def *ErrorDefinition, message string) *HTTPError {
+	if message == "" {
+		return def.New()
+	}
+	return def.Newf("%s", message)
+}
@@ -0,0 +1,144 @@
+package goresponse
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPErrorConstructors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantErrors []map[string]string
+	}{
+		{
+			name:       "not found",
+			err:        NotFound("user", 42),
+			wantStatus: http.StatusNotFound,
+			wantErrors: []map[string]string{{"field": "error", "code": ErrCodeNotFound, "message": "We couldn't find user 42"}},
+		},
+		{
+			name:       "conflict with default message",
+			err:        Conflict(""),
+			wantStatus: http.StatusConflict,
+			wantErrors: []map[string]string{{"field": "error", "code": ErrCodeConflict, "message": "This operation conflicts with an existing resource"}},
+		},
+		{
+			name:       "wrapped unauthorized",
+			err:        fmt.Errorf("authenticate: %w", Unauthorized("Your session has expired")),
+			wantStatus: http.StatusUnauthorized,
+			wantErrors: []map[string]string{{"field": "error", "code": ErrCodeUnauthorized, "message": "Your session has expired"}},
+		},
+		{
+			name:       "forbidden wrapping a database error",
+			err:        Forbidden("").Wrap(sql.ErrNoRows),
+			wantStatus: http.StatusForbidden,
+			wantErrors: []map[string]string{{"field": "error", "code": ErrCodeForbidden, "message": "You don't have permission to access this resource"}},
+		},
+		{
+			name: "validation fields",
+			err: Validation(
+				FieldDetail{Field: "email", Code: "USER_EMAIL_TAKEN", Message: "This email is already registered"},
+				FieldDetail{Field: "age", Message: "Age must be at least 18"},
+			),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantErrors: []map[string]string{
+				{"field": "email", "code": "USER_EMAIL_TAKEN", "message": "This email is already registered"},
+				{"field": "age", "code": ErrCodeValidation, "message": "Age must be at least 18"},
+			},
+		},
+		{
+			name:       "conflict with a field",
+			err:        Conflict("Slug taken").WithField("slug", "This slug is already used"),
+			wantStatus: http.StatusConflict,
+			wantErrors: []map[string]string{{"field": "slug", "code": ErrCodeConflict, "message": "This slug is already used"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := NewStandardErrorResponse(http.StatusBadRequest).AddError(tt.err)
+			assert.Equal(t, tt.wantStatus, response.Code)
+			assert.Equal(t, tt.wantErrors, response.Errors)
+		})
+	}
+}
+
+func TestHTTPErrorUnwrap(t *testing.T) {
+	err := fmt.Errorf("load user: %w", NotFound("user", nil).Wrap(sql.ErrNoRows))
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.True(t, HasErrorCode(err, ErrCodeNotFound))
+	assert.Equal(t, "load user: We couldn't find the user", err.Error())
+}
+
+func TestErrorHandlerResolvesWrappedErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "wrapped HTTPError",
+			err:        fmt.Errorf("update order: %w", Conflict("Order already shipped")),
+			wantStatus: http.StatusConflict,
+			wantCode:   ErrCodeConflict,
+		},
+		{
+			name:       "joined echo error",
+			err:        errors.Join(echo.NewHTTPError(http.StatusForbidden, "Not your order"), Validation(FieldDetail{Field: "note", Message: "Note is too long"})),
+			wantStatus: http.StatusForbidden,
+			wantCode:   "FORBIDDEN",
+		},
+		{
+			name:       "echo error wins over its internal error",
+			err:        echo.NewHTTPError(http.StatusUnauthorized, "login first").SetInternal(sql.ErrNoRows),
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   "UNAUTHORIZED",
+		},
+		{
+			name:       "query parameter error",
+			err:        fmt.Errorf("parse filter: %w", &QueryParamError{Param: "q", Message: "Unknown field in q: color"}),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   ErrCodeInvalidParameter,
+		},
+		{
+			name:       "validation errors",
+			err:        validator.ValidationErrors{MockValidationError{FieldValue: "Email", TagValue: "required"}},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   "VALIDATION_REQUIRED",
+		},
+		{
+			name:       "database error",
+			err:        fmt.Errorf("load order: %w", sql.ErrNoRows),
+			wantStatus: http.StatusNotFound,
+			wantCode:   ErrCodeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/orders/1", nil), rec)
+
+			CustomErrorHandler(tt.err, c)
+
+			var body StandardErrorResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantCode, body.Errors[0]["code"])
+		})
+	}
+}
@@ -0,0 +1,355 @@
+package goresponse
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	// translationRegistry holds the message translations of every registered locale
+	translationRegistry struct {
+		sync.RWMutex
+		universal      *ut.UniversalTranslator
+		defaultLocale  string
+		locales        []string
+		validationTags map[string]struct{}
+		messages       map[string]map[string]string // Texts by translator locale and key
+	}
+	localeContextKey struct{}
+)
+
+// translations has the bundled en and id messages, en being the default locale
+var translations = newTranslationRegistry()
+
+func newTranslationRegistry() *translationRegistry {
+	registry := &translationRegistry{
+		universal:      ut.New(en.New(), en.New(), id.New()),
+		defaultLocale:  "en",
+		locales:        []string{"en", "id"},
+		validationTags: make(map[string]struct{}),
+		messages:       make(map[string]map[string]string),
+	}
+	for _, locale := range registry.locales {
+		trans, _ := registry.universal.GetTranslator(locale)
+		if err := registry.addMessages(trans, bundledMessages(locale)); err != nil {
+			panic(err)
+		}
+	}
+	return registry
+}
+
+// StatusMessageKey is the translation key of the default message of a status, e.g. status.404
+func StatusMessageKey(status int) string {
+	return "status." + strconv.Itoa(status)
+}
+
+// ValidationMessageKey is the translation key of a validator tag, e.g. validation.required.
+// Messages can use {0} for the field label and {1} for the tag param. Size tags can be
+// worded per kind of field with a .string, .number, .items or .time suffix, e.g.
+// validation.min.items. The validation.default message is used for tags without a message.
+func ValidationMessageKey(tag string) string {
+	return "validation." + tag
+}
+
+// ErrorMessageKey is the translation key of the default message of a catalog error,
+// e.g. error.RESOURCE_NOT_FOUND
+func ErrorMessageKey(code string) string {
+	return "error." + code
+}
+
+// FieldLabelKey is the translation key of a field label used in validation messages,
+// e.g. field.first_name. Fields without a label are humanized.
+func FieldLabelKey(field string) string {
+	return "field." + field
+}
+
+// RegisterLocale adds a language, or more messages to a registered one. Messages are
+// keyed by StatusMessageKey, ValidationMessageKey, ErrorMessageKey and FieldLabelKey.
+func RegisterLocale(locale locales.Translator, messages map[string]string) error {
+	translations.Lock()
+	defer translations.Unlock()
+
+	if _, found := translations.universal.GetTranslator(locale.Locale()); !found {
+		if err := translations.universal.AddTranslator(locale, false); err != nil {
+			return err
+		}
+		translations.locales = append(translations.locales, locale.Locale())
+	}
+	trans, _ := translations.universal.GetTranslator(locale.Locale())
+	return translations.addMessages(trans, messages)
+}
+
+// RegisterValidationMessage sets the message of a validator tag, e.g. a custom one, in
+// each of the given locales
+func RegisterValidationMessage(tag string, messages map[string]string) error {
+	for locale, text := range messages {
+		if err := AddTranslation(locale, ValidationMessageKey(tag), text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddTranslation sets or overrides a message of a registered locale
+func AddTranslation(locale, key, text string) error {
+	translations.Lock()
+	defer translations.Unlock()
+
+	trans, found := translations.lookup(locale)
+	if !found {
+		return fmt.Errorf("locale %s is not registered", locale)
+	}
+	return translations.addMessage(trans, key, text)
+}
+
+// SetDefaultLocale sets the locale used when a request doesn't ask for a registered one
+// and for messages missing in the requested locale
+func SetDefaultLocale(locale string) error {
+	translations.Lock()
+	defer translations.Unlock()
+
+	trans, found := translations.lookup(locale)
+	if !found {
+		return fmt.Errorf("locale %s is not registered", locale)
+	}
+	translations.defaultLocale = trans.Locale()
+	return nil
+}
+
+// GetTranslator returns the translator of a registered locale, e.g. to translate
+// validator.ValidationErrors after RegisterValidatorTranslations
+func GetTranslator(locale string) (ut.Translator, bool) {
+	translations.RLock()
+	defer translations.RUnlock()
+	return translations.lookup(locale)
+}
+
+// RegisterValidatorTranslations registers the validation messages of every locale with
+// the validator, so FieldError.Translate returns them. Only tags with a message at the
+// time of the call are registered.
+func RegisterValidatorTranslations(v *validator.Validate) error {
+	translations.RLock()
+	tags := make([]string, 0, len(translations.validationTags))
+	for tag := range translations.validationTags {
+		tags = append(tags, tag)
+	}
+	translators := make([]ut.Translator, 0, len(translations.locales))
+	for _, locale := range translations.locales {
+		trans, _ := translations.universal.GetTranslator(locale)
+		translators = append(translators, trans)
+	}
+	translations.RUnlock()
+
+	noop := func(ut.Translator) error { return nil }
+	translate := func(trans ut.Translator, fe validator.FieldError) string {
+		return validationMessage(trans.Locale(), fe)
+	}
+	for _, trans := range translators {
+		for _, tag := range tags {
+			if err := v.RegisterTranslation(tag, trans, noop, translate); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ContextWithLocale sets the locale of the error messages of a request, taking
+// precedence over the Accept-Language header
+func ContextWithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, locale)
+}
+
+// LocaleFromContext returns the locale set with ContextWithLocale
+func LocaleFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(localeContextKey{}).(string)
+	return locale
+}
+
+// ResolveLocale returns the registered locale of the request from its context or its
+// Accept-Language header, the default locale otherwise
+func ResolveLocale(c echo.Context) string {
+	translations.RLock()
+	defer translations.RUnlock()
+
+	if trans, found := translations.lookup(LocaleFromContext(c.Request().Context())); found {
+		return trans.Locale()
+	}
+	for _, tag := range acceptLanguageTags(c.Request().Header.Get("Accept-Language")) {
+		if trans, found := translations.lookup(tag); found {
+			return trans.Locale()
+		}
+	}
+	return translations.defaultLocale
+}
+
+// acceptLanguageTags returns the language tags of an Accept-Language header ordered by
+// quality, leaving out refused ones
+func acceptLanguageTags(header string) []string {
+	type weightedTag struct {
+		tag     string
+		quality float64
+	}
+	var weighted []weightedTag
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			quality, _ = strconv.ParseFloat(value, 64)
+		}
+		if tag != "" && tag != "*" && quality > 0 {
+			weighted = append(weighted, weightedTag{tag: tag, quality: quality})
+		}
+	}
+	sort.SliceStable(weighted, func(i, j int) bool {
+		return weighted[i].quality > weighted[j].quality
+	})
+
+	tags := make([]string, len(weighted))
+	for i, w := range weighted {
+		tags[i] = w.tag
+	}
+	return tags
+}
+
+// lookup finds the translator of a locale or language tag, falling back to its base
+// language, e.g. id for id-ID
+func (r *translationRegistry) lookup(locale string) (ut.Translator, bool) {
+	if locale == "" {
+		return nil, false
+	}
+	name := strings.ReplaceAll(locale, "-", "_")
+	if trans, found := r.universal.GetTranslator(name); found {
+		return trans, true
+	}
+	base, _, _ := strings.Cut(name, "_")
+	return r.universal.GetTranslator(base)
+}
+
+func (r *translationRegistry) addMessages(trans ut.Translator, messages map[string]string) error {
+	for key, text := range messages {
+		if err := r.addMessage(trans, key, text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *translationRegistry) addMessage(trans ut.Translator, key, text string) error {
+	maxParams := 0
+	tag, isValidation := strings.CutPrefix(key, "validation.")
+	if isValidation {
+		maxParams = 2
+	}
+	if strings.Count(text, "{") > maxParams {
+		return fmt.Errorf("translation %s of %s has more than %d params", key, trans.Locale(), maxParams)
+	}
+	if err := trans.Add(key, text, true); err != nil {
+		return err
+	}
+	if r.messages[trans.Locale()] == nil {
+		r.messages[trans.Locale()] = make(map[string]string)
+	}
+	r.messages[trans.Locale()][key] = text
+	if tag, _, _ = strings.Cut(tag, "."); isValidation && tag != "default" {
+		r.validationTags[tag] = struct{}{}
+	}
+	return nil
+}
+
+// translateFirst returns the first message found for keys, looking in locale before
+// the default locale. Params fill {0}, {1}... in whatever order the message uses them,
+// which universal-translator's T doesn't allow.
+func translateFirst(locale string, keys []string, params ...string) (string, bool) {
+	translations.RLock()
+	defer translations.RUnlock()
+
+	for _, name := range []string{locale, translations.defaultLocale} {
+		trans, found := translations.lookup(name)
+		if !found {
+			continue
+		}
+		for _, key := range keys {
+			if text, ok := translations.messages[trans.Locale()][key]; ok {
+				return fillParams(text, params), true
+			}
+		}
+	}
+	return "", false
+}
+
+// fillParams replaces the {0}, {1}... placeholders of text with params
+func fillParams(text string, params []string) string {
+	if len(params) == 0 {
+		return text
+	}
+	pairs := make([]string, 0, 2*len(params))
+	for i, param := range params {
+		pairs = append(pairs, "{"+strconv.Itoa(i)+"}", param)
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+// defaultLocale returns the locale messages are rendered in until one is chosen
+func defaultLocale() string {
+	translations.RLock()
+	defer translations.RUnlock()
+	return translations.defaultLocale
+}
+
+// statusMessage returns the default message of a status in locale
+func statusMessage(locale string, status int) string {
+	if text, ok := translateFirst(locale, []string{StatusMessageKey(status)}); ok {
+		return text
+	}
+	return statusText(status)
+}
+
+// validationMessage returns the message of a validation error in locale, preferring the
+// message worded for the kind of the field
+func validationMessage(locale string, validationErr validator.FieldError) string {
+	tag := validationErr.Tag()
+	keys := []string{ValidationMessageKey(tag), ValidationMessageKey("default")}
+	if kind := validationKind(validationErr); kind != "" {
+		keys = append([]string{ValidationMessageKey(tag + "." + kind)}, keys...)
+	}
+	label := fieldLabel(locale, validationErr.Field())
+	text, _ := translateFirst(locale, keys, label, validationParam(locale, tag, validationErr.Param()))
+	return text
+}
+
+// fieldLabel returns the translated label of a field, its humanized name otherwise
+func fieldLabel(locale, field string) string {
+	if label, ok := translateFirst(locale, []string{FieldLabelKey(toSnakeCase(field))}); ok {
+		return label
+	}
+	return humanizeFieldName(field)
+}
+
+// localizedMessage returns the default message of the definition in locale
+func (d *ErrorDefinition) localizedMessage(locale string) string {
+	if text, ok := translateFirst(locale, []string{ErrorMessageKey(d.Code)}); ok {
+		return text
+	}
+	return d.Message
+}
+
+// localizedMessage translates the message of errors raised from a catalog definition
+// with its default message
+func (e *HTTPError) localizedMessage(locale string) string {
+	if def, ok := DefaultErrorCatalog.Lookup(e.ErrorCode); ok && def.Message == e.Message {
+		return def.localizedMessage(locale)
+	}
+	return e.Message
+}
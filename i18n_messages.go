@@ -0,0 +1,474 @@
+package goresponse
+
+import "fmt"
+
+// enMessages are the bundled English messages
+var enMessages = map[string]string{
+	"status.400": "We couldn't process your request due to invalid input",
+	"status.401": "Please authenticate to access this resource",
+	"status.402": "Payment Required",
+	"status.403": "You don't have permission to access this resource",
+	"status.404": "The requested resource couldn't be found",
+	"status.405": "Method Not Allowed",
+	"status.406": "Not Acceptable",
+	"status.407": "Proxy Authentication Required",
+	"status.408": "Request Timeout",
+	"status.409": "This operation conflicts with an existing resource",
+	"status.410": "Gone",
+	"status.411": "Length Required",
+	"status.412": "Precondition Failed",
+	"status.413": "Request Entity Too Large",
+	"status.414": "Request URI Too Long",
+	"status.415": "Unsupported Media Type",
+	"status.416": "Requested Range Not Satisfiable",
+	"status.417": "Expectation Failed",
+	"status.418": "I'm a teapot",
+	"status.421": "Misdirected Request",
+	"status.422": "The submitted data failed validation",
+	"status.423": "Locked",
+	"status.424": "Failed Dependency",
+	"status.425": "Too Early",
+	"status.426": "Upgrade Required",
+	"status.428": "Precondition Required",
+	"status.429": "You've exceeded the allowed number of requests. Please try again later",
+	"status.431": "Request Header Fields Too Large",
+	"status.451": "Unavailable For Legal Reasons",
+	"status.499": "The request was canceled by the client",
+	"status.500": "An unexpected error occurred. Our team has been notified",
+	"status.501": "Not Implemented",
+	"status.502": "Bad Gateway",
+	"status.503": "The service is temporarily unavailable. Please try again later",
+	"status.504": "Gateway Timeout",
+	"status.505": "HTTP Version Not Supported",
+	"status.506": "Variant Also Negotiates",
+	"status.507": "Insufficient Storage",
+	"status.508": "Loop Detected",
+	"status.510": "Not Extended",
+	"status.511": "Network Authentication Required",
+
+	"validation.default":              "{0} has an invalid value",
+	"validation.required":             "Please provide {0}",
+	"validation.required_if":          "Please provide {0} when {1}",
+	"validation.required_unless":      "Please provide {0} unless {1}",
+	"validation.required_with":        "Please provide {0} when {1} is present",
+	"validation.required_with_all":    "Please provide {0} when {1} are present",
+	"validation.required_without":     "Please provide {0} when {1} is missing",
+	"validation.required_without_all": "Please provide {0} when {1} are missing",
+	"validation.skip_unless":          "Please provide {0} when {1}",
+	"validation.excluded_if":          "{0} must be left empty when {1}",
+	"validation.excluded_unless":      "{0} must be left empty unless {1}",
+	"validation.excluded_with":        "{0} must be left empty when {1} is present",
+	"validation.excluded_with_all":    "{0} must be left empty when {1} are present",
+	"validation.excluded_without":     "{0} must be left empty when {1} is missing",
+	"validation.excluded_without_all": "{0} must be left empty when {1} are missing",
+	"validation.isdefault":            "{0} must be left empty",
+
+	"validation.len":        "{0} must have a length of {1}",
+	"validation.len.string": "{0} must be exactly {1} characters",
+	"validation.len.number": "{0} must be {1}",
+	"validation.len.items":  "{0} must contain exactly {1} items",
+	"validation.min":        "{0} must be at least {1}",
+	"validation.min.string": "{0} must be at least {1} characters",
+	"validation.min.number": "{0} must be {1} or greater",
+	"validation.min.items":  "{0} must contain at least {1} items",
+	"validation.max":        "{0} must be at most {1}",
+	"validation.max.string": "{0} cannot be longer than {1} characters",
+	"validation.max.number": "{0} must be {1} or less",
+	"validation.max.items":  "{0} cannot contain more than {1} items",
+	"validation.eq":         "{0} must be equal to {1}",
+	"validation.eq.items":   "{0} must contain exactly {1} items",
+	"validation.ne":         "{0} cannot be equal to {1}",
+	"validation.ne.items":   "{0} cannot contain exactly {1} items",
+	"validation.lt":         "{0} must be less than {1}",
+	"validation.lt.string":  "{0} must be shorter than {1} characters",
+	"validation.lt.items":   "{0} must contain fewer than {1} items",
+	"validation.lt.time":    "{0} must be in the past",
+	"validation.lte":        "{0} must be {1} or less",
+	"validation.lte.string": "{0} cannot be longer than {1} characters",
+	"validation.lte.items":  "{0} cannot contain more than {1} items",
+	"validation.lte.time":   "{0} must be now or in the past",
+	"validation.gt":         "{0} must be greater than {1}",
+	"validation.gt.string":  "{0} must be longer than {1} characters",
+	"validation.gt.items":   "{0} must contain more than {1} items",
+	"validation.gt.time":    "{0} must be in the future",
+	"validation.gte":        "{0} must be {1} or greater",
+	"validation.gte.string": "{0} must be at least {1} characters",
+	"validation.gte.items":  "{0} must contain at least {1} items",
+	"validation.gte.time":   "{0} must be now or in the future",
+
+	"validation.eq_ignore_case": "{0} must be equal to {1}",
+	"validation.ne_ignore_case": "{0} cannot be equal to {1}",
+	"validation.eqfield":        "{0} must match {1}",
+	"validation.eqcsfield":      "{0} must match {1}",
+	"validation.nefield":        "{0} must be different from {1}",
+	"validation.necsfield":      "{0} must be different from {1}",
+	"validation.gtfield":        "{0} must be greater than {1}",
+	"validation.gtcsfield":      "{0} must be greater than {1}",
+	"validation.gtefield":       "{0} must be greater than or equal to {1}",
+	"validation.gtecsfield":     "{0} must be greater than or equal to {1}",
+	"validation.ltfield":        "{0} must be less than {1}",
+	"validation.ltcsfield":      "{0} must be less than {1}",
+	"validation.ltefield":       "{0} must be less than or equal to {1}",
+	"validation.ltecsfield":     "{0} must be less than or equal to {1}",
+	"validation.fieldcontains":  "{0} must contain {1}",
+	"validation.fieldexcludes":  "{0} cannot contain {1}",
+
+	"validation.alpha":           "{0} can only contain letters",
+	"validation.alphanum":        "{0} can only contain letters and numbers",
+	"validation.alphaunicode":    "{0} can only contain letters",
+	"validation.alphanumunicode": "{0} can only contain letters and numbers",
+	"validation.ascii":           "{0} can only contain ASCII characters",
+	"validation.printascii":      "{0} can only contain printable ASCII characters",
+	"validation.multibyte":       "{0} must contain multibyte characters",
+	"validation.lowercase":       "{0} must be lowercase",
+	"validation.uppercase":       "{0} must be uppercase",
+	"validation.contains":        "{0} must contain {1}",
+	"validation.containsany":     "{0} must contain at least one of {1}",
+	"validation.containsrune":    "{0} must contain {1}",
+	"validation.excludes":        "{0} cannot contain {1}",
+	"validation.excludesall":     "{0} cannot contain any of {1}",
+	"validation.excludesrune":    "{0} cannot contain {1}",
+	"validation.startswith":      "{0} must start with {1}",
+	"validation.endswith":        "{0} must end with {1}",
+	"validation.startsnotwith":   "{0} cannot start with {1}",
+	"validation.endsnotwith":     "{0} cannot end with {1}",
+	"validation.oneof":           "{0} must be one of {1}",
+	"validation.unique":          "{0} must contain unique values",
+	"validation.boolean":         "{0} must be true or false",
+	"validation.numeric":         "{0} must be a number",
+	"validation.number":          "{0} can only contain digits",
+	"validation.file":            "{0} must be an existing file",
+	"validation.dir":             "{0} must be an existing directory",
+	"validation.image":           "{0} must be an image",
+	"validation.luhn_checksum":   "{0} must have a valid checksum",
+
+	"validation.postcode_iso3166_alpha2":       "{0} must be a valid postcode for {1}",
+	"validation.postcode_iso3166_alpha2_field": "{0} must be a valid postcode for {1}",
+
+	"error.INVALID_PARAMETER":      "A query parameter is invalid",
+	"error.INVALID_TYPE":           "A value has the wrong type",
+	"error.MALFORMED_JSON":         "The request body isn't valid JSON",
+	"error.INCOMPLETE_BODY":        "The request body ended unexpectedly",
+	"error.UNKNOWN_FIELD":          "The request body has a field we don't know",
+	"error.BODY_TOO_LARGE":         "The request body is too large",
+	"error.UNSUPPORTED_MEDIA_TYPE": "The content type of the request body isn't supported",
+	"error.RESOURCE_NOT_FOUND":     "We couldn't find what you're looking for",
+	"error.CONFLICT":               "This operation conflicts with an existing resource",
+	"error.UNAUTHORIZED":           "Please authenticate to access this resource",
+	"error.FORBIDDEN":              "You don't have permission to access this resource",
+	"error.VALIDATION_FAILED":      "The submitted data failed validation",
+	"error.DUPLICATE_RESOURCE":     "This information already exists in our system",
+	"error.INVALID_REFERENCE":      "This operation references invalid or non-existent data",
+	"error.MISSING_REQUIRED_DATA":  "Required information is missing",
+	"error.INVALID_DATA_FORMAT":    "The provided data format is invalid",
+	"error.CHECK_VIOLATION":        "The provided data breaks one of our data rules",
+	"error.RESOURCE_IN_USE":        "This data is still used by other data",
+	"error.CONCURRENT_UPDATE":      "This data was changed by another request at the same time. Please try again",
+	"error.DATABASE_TIMEOUT":       "The database took too long to respond. Please try again",
+	"error.DATABASE_ERROR":         "An unexpected database error occurred",
+	"error.DATABASE_UNAVAILABLE":   "We're having trouble connecting to our database. Please try again",
+	"error.REQUEST_CANCELED":       "The request was canceled before it completed",
+	"error.REQUEST_TIMEOUT":        "The request took too long to complete. Please try again",
+	"error.RATE_LIMITED":           "You've exceeded the allowed number of requests. Please try again later",
+	"error.SERVICE_UNAVAILABLE":    "The service is temporarily unavailable. Please try again later",
+	"error.INTERNAL_ERROR":         "An unexpected error occurred",
+}
+
+// idMessages are the bundled Indonesian messages
+var idMessages = map[string]string{
+	"status.400": "Kami tidak dapat memproses permintaan Anda karena input tidak valid",
+	"status.401": "Silakan melakukan autentikasi untuk mengakses sumber daya ini",
+	"status.402": "Pembayaran Diperlukan",
+	"status.403": "Anda tidak memiliki izin untuk mengakses sumber daya ini",
+	"status.404": "Sumber daya yang diminta tidak ditemukan",
+	"status.405": "Metode Tidak Diizinkan",
+	"status.406": "Tidak Dapat Diterima",
+	"status.407": "Autentikasi Proxy Diperlukan",
+	"status.408": "Waktu Permintaan Habis",
+	"status.409": "Operasi ini bertentangan dengan sumber daya yang sudah ada",
+	"status.410": "Sudah Tidak Tersedia",
+	"status.411": "Panjang Konten Diperlukan",
+	"status.412": "Prasyarat Gagal",
+	"status.413": "Permintaan Terlalu Besar",
+	"status.414": "URI Permintaan Terlalu Panjang",
+	"status.415": "Jenis Media Tidak Didukung",
+	"status.416": "Rentang Tidak Dapat Dipenuhi",
+	"status.417": "Ekspektasi Gagal",
+	"status.418": "Saya Adalah Teko Teh",
+	"status.421": "Permintaan Salah Arah",
+	"status.422": "Data yang dikirim gagal divalidasi",
+	"status.423": "Terkunci",
+	"status.424": "Dependensi Gagal",
+	"status.425": "Terlalu Dini",
+	"status.426": "Pembaruan Protokol Diperlukan",
+	"status.428": "Prasyarat Diperlukan",
+	"status.429": "Anda telah melebihi batas jumlah permintaan. Silakan coba lagi nanti",
+	"status.431": "Header Permintaan Terlalu Besar",
+	"status.451": "Tidak Tersedia karena Alasan Hukum",
+	"status.499": "Permintaan dibatalkan oleh klien",
+	"status.500": "Terjadi kesalahan yang tidak terduga. Tim kami telah diberi tahu",
+	"status.501": "Belum Diimplementasikan",
+	"status.502": "Gateway Buruk",
+	"status.503": "Layanan sedang tidak tersedia. Silakan coba lagi nanti",
+	"status.504": "Waktu Gateway Habis",
+	"status.505": "Versi HTTP Tidak Didukung",
+	"status.506": "Varian Juga Bernegosiasi",
+	"status.507": "Penyimpanan Tidak Cukup",
+	"status.508": "Perulangan Terdeteksi",
+	"status.510": "Tidak Diperluas",
+	"status.511": "Autentikasi Jaringan Diperlukan",
+
+	"validation.default":              "{0} memiliki nilai yang tidak valid",
+	"validation.required":             "Mohon isi {0}",
+	"validation.required_if":          "Mohon isi {0} jika {1}",
+	"validation.required_unless":      "Mohon isi {0} kecuali {1}",
+	"validation.required_with":        "Mohon isi {0} jika {1} diisi",
+	"validation.required_with_all":    "Mohon isi {0} jika {1} diisi",
+	"validation.required_without":     "Mohon isi {0} jika {1} tidak diisi",
+	"validation.required_without_all": "Mohon isi {0} jika {1} tidak diisi",
+	"validation.skip_unless":          "Mohon isi {0} jika {1}",
+	"validation.excluded_if":          "{0} harus dikosongkan jika {1}",
+	"validation.excluded_unless":      "{0} harus dikosongkan kecuali {1}",
+	"validation.excluded_with":        "{0} harus dikosongkan jika {1} diisi",
+	"validation.excluded_with_all":    "{0} harus dikosongkan jika {1} diisi",
+	"validation.excluded_without":     "{0} harus dikosongkan jika {1} tidak diisi",
+	"validation.excluded_without_all": "{0} harus dikosongkan jika {1} tidak diisi",
+	"validation.isdefault":            "{0} harus dikosongkan",
+
+	"validation.len":        "{0} harus memiliki panjang {1}",
+	"validation.len.string": "{0} harus tepat {1} karakter",
+	"validation.len.number": "{0} harus {1}",
+	"validation.len.items":  "{0} harus berisi tepat {1} item",
+	"validation.min":        "{0} minimal {1}",
+	"validation.min.string": "{0} minimal {1} karakter",
+	"validation.min.number": "{0} harus {1} atau lebih",
+	"validation.min.items":  "{0} harus berisi minimal {1} item",
+	"validation.max":        "{0} maksimal {1}",
+	"validation.max.string": "{0} tidak boleh lebih dari {1} karakter",
+	"validation.max.number": "{0} harus {1} atau kurang",
+	"validation.max.items":  "{0} tidak boleh berisi lebih dari {1} item",
+	"validation.eq":         "{0} harus sama dengan {1}",
+	"validation.eq.items":   "{0} harus berisi tepat {1} item",
+	"validation.ne":         "{0} tidak boleh sama dengan {1}",
+	"validation.ne.items":   "{0} tidak boleh berisi tepat {1} item",
+	"validation.lt":         "{0} harus kurang dari {1}",
+	"validation.lt.string":  "{0} harus kurang dari {1} karakter",
+	"validation.lt.items":   "{0} harus berisi kurang dari {1} item",
+	"validation.lt.time":    "{0} harus di masa lalu",
+	"validation.lte":        "{0} harus {1} atau kurang",
+	"validation.lte.string": "{0} tidak boleh lebih dari {1} karakter",
+	"validation.lte.items":  "{0} tidak boleh berisi lebih dari {1} item",
+	"validation.lte.time":   "{0} harus sekarang atau di masa lalu",
+	"validation.gt":         "{0} harus lebih besar dari {1}",
+	"validation.gt.string":  "{0} harus lebih dari {1} karakter",
+	"validation.gt.items":   "{0} harus berisi lebih dari {1} item",
+	"validation.gt.time":    "{0} harus di masa depan",
+	"validation.gte":        "{0} harus {1} atau lebih",
+	"validation.gte.string": "{0} minimal {1} karakter",
+	"validation.gte.items":  "{0} harus berisi minimal {1} item",
+	"validation.gte.time":   "{0} harus sekarang atau di masa depan",
+
+	"validation.eq_ignore_case": "{0} harus sama dengan {1}",
+	"validation.ne_ignore_case": "{0} tidak boleh sama dengan {1}",
+	"validation.eqfield":        "{0} harus sama dengan {1}",
+	"validation.eqcsfield":      "{0} harus sama dengan {1}",
+	"validation.nefield":        "{0} harus berbeda dari {1}",
+	"validation.necsfield":      "{0} harus berbeda dari {1}",
+	"validation.gtfield":        "{0} harus lebih besar dari {1}",
+	"validation.gtcsfield":      "{0} harus lebih besar dari {1}",
+	"validation.gtefield":       "{0} harus lebih besar dari atau sama dengan {1}",
+	"validation.gtecsfield":     "{0} harus lebih besar dari atau sama dengan {1}",
+	"validation.ltfield":        "{0} harus kurang dari {1}",
+	"validation.ltcsfield":      "{0} harus kurang dari {1}",
+	"validation.ltefield":       "{0} harus kurang dari atau sama dengan {1}",
+	"validation.ltecsfield":     "{0} harus kurang dari atau sama dengan {1}",
+	"validation.fieldcontains":  "{0} harus mengandung {1}",
+	"validation.fieldexcludes":  "{0} tidak boleh mengandung {1}",
+
+	"validation.alpha":           "{0} hanya boleh berisi huruf",
+	"validation.alphanum":        "{0} hanya boleh berisi huruf dan angka",
+	"validation.alphaunicode":    "{0} hanya boleh berisi huruf",
+	"validation.alphanumunicode": "{0} hanya boleh berisi huruf dan angka",
+	"validation.ascii":           "{0} hanya boleh berisi karakter ASCII",
+	"validation.printascii":      "{0} hanya boleh berisi karakter ASCII yang dapat dicetak",
+	"validation.multibyte":       "{0} harus berisi karakter multibyte",
+	"validation.lowercase":       "{0} harus berupa huruf kecil",
+	"validation.uppercase":       "{0} harus berupa huruf besar",
+	"validation.contains":        "{0} harus mengandung {1}",
+	"validation.containsany":     "{0} harus mengandung salah satu dari {1}",
+	"validation.containsrune":    "{0} harus mengandung {1}",
+	"validation.excludes":        "{0} tidak boleh mengandung {1}",
+	"validation.excludesall":     "{0} tidak boleh mengandung satu pun dari {1}",
+	"validation.excludesrune":    "{0} tidak boleh mengandung {1}",
+	"validation.startswith":      "{0} harus diawali dengan {1}",
+	"validation.endswith":        "{0} harus diakhiri dengan {1}",
+	"validation.startsnotwith":   "{0} tidak boleh diawali dengan {1}",
+	"validation.endsnotwith":     "{0} tidak boleh diakhiri dengan {1}",
+	"validation.oneof":           "{0} harus salah satu dari {1}",
+	"validation.unique":          "{0} harus berisi nilai yang unik",
+	"validation.boolean":         "{0} harus bernilai true atau false",
+	"validation.numeric":         "{0} harus berupa angka",
+	"validation.number":          "{0} hanya boleh berisi digit",
+	"validation.file":            "{0} harus berupa berkas yang ada",
+	"validation.dir":             "{0} harus berupa direktori yang ada",
+	"validation.image":           "{0} harus berupa gambar",
+	"validation.luhn_checksum":   "{0} harus memiliki checksum yang valid",
+
+	"validation.postcode_iso3166_alpha2":       "{0} harus berupa kode pos yang valid untuk {1}",
+	"validation.postcode_iso3166_alpha2_field": "{0} harus berupa kode pos yang valid untuk {1}",
+
+	"error.INVALID_PARAMETER":      "Parameter kueri tidak valid",
+	"error.INVALID_TYPE":           "Sebuah nilai memiliki tipe yang salah",
+	"error.MALFORMED_JSON":         "Isi permintaan bukan JSON yang valid",
+	"error.INCOMPLETE_BODY":        "Isi permintaan berakhir secara tiba-tiba",
+	"error.UNKNOWN_FIELD":          "Isi permintaan memiliki kolom yang tidak kami kenal",
+	"error.BODY_TOO_LARGE":         "Isi permintaan terlalu besar",
+	"error.UNSUPPORTED_MEDIA_TYPE": "Jenis konten isi permintaan tidak didukung",
+	"error.RESOURCE_NOT_FOUND":     "Kami tidak dapat menemukan yang Anda cari",
+	"error.CONFLICT":               "Operasi ini bertentangan dengan sumber daya yang sudah ada",
+	"error.UNAUTHORIZED":           "Silakan melakukan autentikasi untuk mengakses sumber daya ini",
+	"error.FORBIDDEN":              "Anda tidak memiliki izin untuk mengakses sumber daya ini",
+	"error.VALIDATION_FAILED":      "Data yang dikirim gagal divalidasi",
+	"error.DUPLICATE_RESOURCE":     "Informasi ini sudah ada di sistem kami",
+	"error.INVALID_REFERENCE":      "Operasi ini merujuk ke data yang tidak valid atau tidak ada",
+	"error.MISSING_REQUIRED_DATA":  "Informasi yang diperlukan belum lengkap",
+	"error.INVALID_DATA_FORMAT":    "Format data yang diberikan tidak valid",
+	"error.CHECK_VIOLATION":        "Data yang diberikan melanggar salah satu aturan data kami",
+	"error.RESOURCE_IN_USE":        "Data ini masih digunakan oleh data lain",
+	"error.CONCURRENT_UPDATE":      "Data ini diubah oleh permintaan lain pada saat yang sama. Silakan coba lagi",
+	"error.DATABASE_TIMEOUT":       "Basis data terlalu lama merespons. Silakan coba lagi",
+	"error.DATABASE_ERROR":         "Terjadi kesalahan basis data yang tidak terduga",
+	"error.DATABASE_UNAVAILABLE":   "Kami mengalami kendala saat terhubung ke basis data. Silakan coba lagi",
+	"error.REQUEST_CANCELED":       "Permintaan dibatalkan sebelum selesai",
+	"error.REQUEST_TIMEOUT":        "Permintaan terlalu lama untuk diselesaikan. Silakan coba lagi",
+	"error.RATE_LIMITED":           "Anda telah melebihi batas jumlah permintaan. Silakan coba lagi nanti",
+	"error.SERVICE_UNAVAILABLE":    "Layanan sedang tidak tersedia. Silakan coba lagi nanti",
+	"error.INTERNAL_ERROR":         "Terjadi kesalahan yang tidak terduga",
+}
+
+// formatMessageTemplates word the messages of the format tags in validationFormats
+var formatMessageTemplates = map[string]string{
+	"en": "Please enter a valid %s for {0}",
+	"id": "Mohon masukkan %s yang valid untuk {0}",
+}
+
+// validationFormats names the format checked by a validator tag in each bundled locale
+var validationFormats = map[string][2]string{
+	"email":                      {"email address", "alamat email"},
+	"url":                        {"URL", "URL"},
+	"http_url":                   {"HTTP URL", "URL HTTP"},
+	"uri":                        {"URI", "URI"},
+	"urn_rfc2141":                {"URN", "URN"},
+	"datauri":                    {"data URI", "data URI"},
+	"datetime":                   {"date and time", "tanggal dan waktu"},
+	"timezone":                   {"time zone", "zona waktu"},
+	"filepath":                   {"file path", "path berkas"},
+	"dirpath":                    {"directory path", "path direktori"},
+	"e164":                       {"phone number in E.164 format", "nomor telepon format E.164"},
+	"hexadecimal":                {"hexadecimal value", "nilai heksadesimal"},
+	"hexcolor":                   {"hex color", "warna hex"},
+	"rgb":                        {"RGB color", "warna RGB"},
+	"rgba":                       {"RGBA color", "warna RGBA"},
+	"hsl":                        {"HSL color", "warna HSL"},
+	"hsla":                       {"HSLA color", "warna HSLA"},
+	"iscolor":                    {"color", "warna"},
+	"base32":                     {"base32 string", "string base32"},
+	"base64":                     {"base64 string", "string base64"},
+	"base64url":                  {"base64 URL string", "string base64 URL"},
+	"base64rawurl":               {"unpadded base64 URL string", "string base64 URL tanpa padding"},
+	"isbn":                       {"ISBN", "ISBN"},
+	"isbn10":                     {"ISBN-10", "ISBN-10"},
+	"isbn13":                     {"ISBN-13", "ISBN-13"},
+	"issn":                       {"ISSN", "ISSN"},
+	"eth_addr":                   {"Ethereum address", "alamat Ethereum"},
+	"eth_addr_checksum":          {"checksummed Ethereum address", "alamat Ethereum dengan checksum"},
+	"btc_addr":                   {"Bitcoin address", "alamat Bitcoin"},
+	"btc_addr_bech32":            {"Bech32 Bitcoin address", "alamat Bitcoin Bech32"},
+	"uuid":                       {"UUID", "UUID"},
+	"uuid3":                      {"version 3 UUID", "UUID versi 3"},
+	"uuid4":                      {"version 4 UUID", "UUID versi 4"},
+	"uuid5":                      {"version 5 UUID", "UUID versi 5"},
+	"uuid_rfc4122":               {"RFC 4122 UUID", "UUID RFC 4122"},
+	"uuid3_rfc4122":              {"RFC 4122 version 3 UUID", "UUID versi 3 RFC 4122"},
+	"uuid4_rfc4122":              {"RFC 4122 version 4 UUID", "UUID versi 4 RFC 4122"},
+	"uuid5_rfc4122":              {"RFC 4122 version 5 UUID", "UUID versi 5 RFC 4122"},
+	"ulid":                       {"ULID", "ULID"},
+	"md4":                        {"MD4 hash", "hash MD4"},
+	"md5":                        {"MD5 hash", "hash MD5"},
+	"sha256":                     {"SHA-256 hash", "hash SHA-256"},
+	"sha384":                     {"SHA-384 hash", "hash SHA-384"},
+	"sha512":                     {"SHA-512 hash", "hash SHA-512"},
+	"ripemd128":                  {"RIPEMD-128 hash", "hash RIPEMD-128"},
+	"ripemd160":                  {"RIPEMD-160 hash", "hash RIPEMD-160"},
+	"tiger128":                   {"TIGER128 hash", "hash TIGER128"},
+	"tiger160":                   {"TIGER160 hash", "hash TIGER160"},
+	"tiger192":                   {"TIGER192 hash", "hash TIGER192"},
+	"latitude":                   {"latitude", "garis lintang"},
+	"longitude":                  {"longitude", "garis bujur"},
+	"ssn":                        {"social security number", "nomor jaminan sosial"},
+	"ip":                         {"IP address", "alamat IP"},
+	"ipv4":                       {"IPv4 address", "alamat IPv4"},
+	"ipv6":                       {"IPv6 address", "alamat IPv6"},
+	"cidr":                       {"CIDR notation", "notasi CIDR"},
+	"cidrv4":                     {"IPv4 CIDR notation", "notasi CIDR IPv4"},
+	"cidrv6":                     {"IPv6 CIDR notation", "notasi CIDR IPv6"},
+	"tcp_addr":                   {"TCP address", "alamat TCP"},
+	"tcp4_addr":                  {"TCP4 address", "alamat TCP4"},
+	"tcp6_addr":                  {"TCP6 address", "alamat TCP6"},
+	"udp_addr":                   {"UDP address", "alamat UDP"},
+	"udp4_addr":                  {"UDP4 address", "alamat UDP4"},
+	"udp6_addr":                  {"UDP6 address", "alamat UDP6"},
+	"ip_addr":                    {"resolvable IP address", "alamat IP yang dapat di-resolve"},
+	"ip4_addr":                   {"resolvable IPv4 address", "alamat IPv4 yang dapat di-resolve"},
+	"ip6_addr":                   {"resolvable IPv6 address", "alamat IPv6 yang dapat di-resolve"},
+	"unix_addr":                  {"Unix socket address", "alamat soket Unix"},
+	"mac":                        {"MAC address", "alamat MAC"},
+	"hostname":                   {"hostname", "nama host"},
+	"hostname_rfc1123":           {"hostname", "nama host"},
+	"hostname_port":              {"host and port", "host dan port"},
+	"fqdn":                       {"fully qualified domain name", "nama domain lengkap"},
+	"dns_rfc1035_label":          {"DNS label", "label DNS"},
+	"html":                       {"HTML", "HTML"},
+	"html_encoded":               {"HTML-encoded value", "nilai ter-encode HTML"},
+	"url_encoded":                {"URL-encoded value", "nilai ter-encode URL"},
+	"json":                       {"JSON", "JSON"},
+	"jwt":                        {"JWT", "JWT"},
+	"iso3166_1_alpha2":           {"two-letter country code", "kode negara dua huruf"},
+	"iso3166_1_alpha2_eu":        {"two-letter EU country code", "kode negara Uni Eropa dua huruf"},
+	"iso3166_1_alpha3":           {"three-letter country code", "kode negara tiga huruf"},
+	"iso3166_1_alpha3_eu":        {"three-letter EU country code", "kode negara Uni Eropa tiga huruf"},
+	"iso3166_1_alpha_numeric":    {"numeric country code", "kode negara numerik"},
+	"iso3166_1_alpha_numeric_eu": {"numeric EU country code", "kode negara Uni Eropa numerik"},
+	"country_code":               {"country code", "kode negara"},
+	"eu_country_code":            {"EU country code", "kode negara Uni Eropa"},
+	"iso3166_2":                  {"country subdivision code", "kode subdivisi negara"},
+	"iso4217":                    {"currency code", "kode mata uang"},
+	"iso4217_numeric":            {"numeric currency code", "kode mata uang numerik"},
+	"bcp47_language_tag":         {"language tag", "tag bahasa"},
+	"bic":                        {"BIC code", "kode BIC"},
+	"semver":                     {"semantic version", "versi semantik"},
+	"credit_card":                {"credit card number", "nomor kartu kredit"},
+	"cve":                        {"CVE identifier", "ID CVE"},
+	"mongodb":                    {"MongoDB ObjectID", "ObjectID MongoDB"},
+	"mongodb_connection_string":  {"MongoDB connection string", "string koneksi MongoDB"},
+	"cron":                       {"cron expression", "ekspresi cron"},
+	"spicedb":                    {"SpiceDB identifier", "ID SpiceDB"},
+}
+
+// bundledMessages returns the bundled messages of en or id, including the format tag
+// messages
+func bundledMessages(locale string) map[string]string {
+	messages, nameIndex := enMessages, 0
+	if locale == "id" {
+		messages, nameIndex = idMessages, 1
+	}
+
+	all := make(map[string]string, len(messages)+len(validationFormats))
+	for tag, names := range validationFormats {
+		all[ValidationMessageKey(tag)] = fmt.Sprintf(formatMessageTemplates[locale], names[nameIndex])
+	}
+	for key, text := range messages {
+		all[key] = text
+	}
+	return all
+}
@@ -0,0 +1,189 @@
+package goresponse
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/locales/ms"
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type localizedUser struct {
+	FirstName string `validate:"required"`
+	Password  string `validate:"min=8"`
+}
+
+func TestLocalizedErrorResponse(t *testing.T) {
+	validationErr := validator.New().Struct(localizedUser{Password: "secret"})
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		ctxLocale      string
+		wantMessage    string
+		wantErrors     []string
+	}{
+		{
+			name:        "default locale",
+			wantMessage: "The submitted data failed validation",
+			wantErrors:  []string{"Please provide first name", "password must be at least 8 characters"},
+		},
+		{
+			name:           "accept language",
+			acceptLanguage: "id-ID,id;q=0.9,en;q=0.8",
+			wantMessage:    "Data yang dikirim gagal divalidasi",
+			wantErrors:     []string{"Mohon isi first name", "password minimal 8 karakter"},
+		},
+		{
+			name:           "preferred language by quality",
+			acceptLanguage: "ja;q=0.9, en;q=0.5, id",
+			wantMessage:    "Data yang dikirim gagal divalidasi",
+			wantErrors:     []string{"Mohon isi first name", "password minimal 8 karakter"},
+		},
+		{
+			name:           "context locale wins",
+			acceptLanguage: "id",
+			ctxLocale:      "en",
+			wantMessage:    "The submitted data failed validation",
+			wantErrors:     []string{"Please provide first name", "password must be at least 8 characters"},
+		},
+		{
+			name:           "unsupported language",
+			acceptLanguage: "ja",
+			wantMessage:    "The submitted data failed validation",
+			wantErrors:     []string{"Please provide first name", "password must be at least 8 characters"},
+		},
+	}
+
+	e := echo.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/users", nil)
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+			if tt.ctxLocale != "" {
+				req = req.WithContext(ContextWithLocale(req.Context(), tt.ctxLocale))
+			}
+			rec := httptest.NewRecorder()
+
+			err := NewStandardErrorResponse(http.StatusUnprocessableEntity).AddError(validationErr).JSON(e.NewContext(req, rec))
+			assert.NoError(t, err)
+
+			var response StandardErrorResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantMessage, response.Message)
+			var messages []string
+			for _, entry := range response.Errors {
+				messages = append(messages, entry["message"])
+			}
+			assert.Equal(t, tt.wantErrors, messages)
+		})
+	}
+}
+
+func TestWithLocaleKeepsCustomMessages(t *testing.T) {
+	response := NewStandardErrorResponse(http.StatusNotFound).
+		AddError(errDefNotFound.New()).
+		AddError(&HTTPError{Code: http.StatusNotFound, Message: "user 42 not found", ErrorCode: ErrCodeNotFound}).
+		AddMessageError("id", "Unknown id")
+	response.Message = "Custom message"
+	response.WithLocale("id")
+
+	assert.Equal(t, "Custom message", response.Message)
+	assert.Equal(t, "Kami tidak dapat menemukan yang Anda cari", response.Errors[0]["message"])
+	assert.Equal(t, "user 42 not found", response.Errors[1]["message"])
+	assert.Equal(t, "Unknown id", response.Errors[2]["message"])
+
+	response.WithLocale("en")
+	assert.Equal(t, "We couldn't find what you're looking for", response.Errors[0]["message"])
+}
+
+func TestLocalizedErrorHandler(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "id")
+	rec := httptest.NewRecorder()
+
+	CustomErrorHandler(errors.New("cache unavailable"), e.NewContext(req, rec))
+
+	var response StandardErrorResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "Terjadi kesalahan yang tidak terduga. Tim kami telah diberi tahu", response.Message)
+	assert.Equal(t, []map[string]string{
+		{"field": "error", "code": "INTERNAL_ERROR", "message": "Terjadi kesalahan yang tidak terduga"},
+	}, response.Errors)
+}
+
+func TestRegisterLocale(t *testing.T) {
+	err := RegisterLocale(ms.New(), map[string]string{
+		StatusMessageKey(http.StatusNotFound):            "Sumber yang diminta tidak dijumpai",
+		StatusMessageKey(http.StatusUnprocessableEntity): "Data yang dihantar gagal disahkan",
+		ValidationMessageKey("required"):                 "Sila berikan {0}",
+		FieldLabelKey("first_name"):                      "nama pertama",
+	})
+	assert.NoError(t, err)
+	err = RegisterLocale(ms.New(), map[string]string{StatusMessageKey(http.StatusConflict): "Konflik {0}"})
+	assert.EqualError(t, err, "translation status.409 of ms has more than 0 params")
+
+	response := NewStandardErrorResponse(http.StatusNotFound).
+		AddError(validator.ValidationErrors{MockValidationError{FieldValue: "FirstName", TagValue: "required"}}).
+		AddError(validator.ValidationErrors{MockValidationError{FieldValue: "Email", TagValue: "email"}}).
+		WithLocale("ms-MY")
+
+	// The message follows the status of the added validation errors
+	assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+	assert.Equal(t, "Data yang dihantar gagal disahkan", response.Message)
+	assert.Equal(t, "Sila berikan nama pertama", response.Errors[0]["message"])
+	// Messages missing in a locale fall back to the default locale
+	assert.Equal(t, "Please enter a valid email address for email", response.Errors[1]["message"])
+
+	assert.EqualError(t, AddTranslation("xx", StatusMessageKey(http.StatusNotFound), "Not here"), "locale xx is not registered")
+	assert.NoError(t, AddTranslation("ms", StatusMessageKey(http.StatusNotFound), "Tiada di sini"))
+	assert.Equal(t, "Tiada di sini", NewStandardErrorResponse(http.StatusNotFound).WithLocale("ms").Message)
+}
+
+func TestRegisterValidatorTranslations(t *testing.T) {
+	v := validator.New()
+	assert.NoError(t, RegisterValidatorTranslations(v))
+
+	trans, ok := GetTranslator("id")
+	assert.True(t, ok)
+
+	err := v.Struct(localizedUser{Password: "secret"})
+	assert.Equal(t, validator.ValidationErrorsTranslations{
+		"localizedUser.FirstName": "Mohon isi first name",
+		"localizedUser.Password":  "password minimal 8 karakter",
+	}, err.(validator.ValidationErrors).Translate(trans))
+}
+
+func TestReorderedPlaceholders(t *testing.T) {
+	key := ValidationMessageKey("min.string")
+	assert.NoError(t, AddTranslation("id", key, "Minimal {1} karakter untuk {0}"))
+	t.Cleanup(func() { _ = AddTranslation("id", key, idMessages[key]) })
+
+	err := validator.New().Struct(localizedUser{FirstName: "Ann", Password: "secret"})
+	response := NewStandardErrorResponse(http.StatusUnprocessableEntity).AddError(err).WithLocale("id")
+	assert.Equal(t, "Minimal 8 karakter untuk password", response.Errors[0]["message"])
+}
+
+func TestAcceptLanguageTags(t *testing.T) {
+	tests := []struct {
+		header string
+		want   []string
+	}{
+		{header: "", want: []string{}},
+		{header: "id", want: []string{"id"}},
+		{header: "en;q=0.5, id-ID", want: []string{"id-ID", "en"}},
+		{header: "fr;q=0, *, en-US;q=0.8", want: []string{"en-US"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, acceptLanguageTags(tt.header))
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package goresponse
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// IncludeTree is the parsed include param, e.g. include=author,comments.user becomes
+// {"author": {}, "comments": {"user": {}}}
+type IncludeTree map[string]IncludeTree
+
+// WithIncludes validates the include param against allowed relation paths, such as
+// "author" or "comments.user", and rejects paths nested deeper than maxDepth (0 means
+// no limit). The expand param is accepted as an alias of include, both are merged into
+// Include when sent together.
+func WithIncludes(maxDepth int, allowed ...string) ParseOption {
+	allowedTree := buildIncludeTree(allowed)
+	return func(cfg *parseConfig) {
+		cfg.postParse = append(cfg.postParse, func(filter *FilterOptions, values url.Values) error {
+			delete(filter.DynamicFields, "expand")
+			if err := validateIncludes(filter.Include, "include", maxDepth, allowedTree); err != nil {
+				return err
+			}
+			expand := cleanStringSlice(strings.Split(values.Get("expand"), ","))
+			if err := validateIncludes(expand, "expand", maxDepth, allowedTree); err != nil {
+				return err
+			}
+			filter.Include = mergeIncludes(filter.Include, expand)
+			return nil
+		})
+	}
+}
+
+// mergeIncludes appends the expanded relations that aren't included yet
+func mergeIncludes(include, expand []string) []string {
+	for _, path := range expand {
+		if !slices.Contains(include, path) {
+			include = append(include, path)
+		}
+	}
+	return include
+}
+
+func validateIncludes(includes []string, param string, maxDepth int, allowed IncludeTree) error {
+	for _, path := range includes {
+		segments := strings.Split(path, ".")
+		for _, segment := range segments {
+			if strings.TrimSpace(segment) == "" {
+				return &QueryParamError{Param: param, Message: fmt.Sprintf("Invalid relation path %q", path)}
+			}
+		}
+		if maxDepth > 0 && len(segments) > maxDepth {
+			return &QueryParamError{Param: param, Message: fmt.Sprintf("Relation %q is nested deeper than %d levels", path, maxDepth)}
+		}
+		if !allowed.Has(path) {
+			return &QueryParamError{Param: param, Message: fmt.Sprintf("Unknown relation %q", path)}
+		}
+	}
+	return nil
+}
+
+func buildIncludeTree(paths []string) IncludeTree {
+	tree := IncludeTree{}
+	for _, path := range paths {
+		node := tree
+		for _, segment := range strings.Split(path, ".") {
+			if node[segment] == nil {
+				node[segment] = IncludeTree{}
+			}
+			node = node[segment]
+		}
+	}
+	return tree
+}
+
+// Has reports whether the dot separated relation path is part of the tree.
+// Including comments.user also includes comments.
+func (t IncludeTree) Has(path string) bool {
+	node := t
+	for _, segment := range strings.Split(path, ".") {
+		child, ok := node[segment]
+		if !ok {
+			return false
+		}
+		node = child
+	}
+	return true
+}
+
+// IncludeTree returns the requested relations as a tree
+func (f *FilterOptions) IncludeTree() IncludeTree {
+	return buildIncludeTree(f.Include)
+}
+
+// Includes reports whether the relation path, e.g. "comments.user", was requested
+func (f *FilterOptions) Includes(path string) bool {
+	return f.IncludeTree().Has(path)
+}
+
+// AddIncluded side-loads related resources under the given relation name
+func (r *PaginatedResponse) AddIncluded(name string, data interface{}) *PaginatedResponse {
+	if r.Included == nil {
+		r.Included = make(map[string]interface{})
+	}
+	r.Included[name] = data
+	return r
+}
@@ -0,0 +1,105 @@
+package goresponse
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseURLValuesIncludes(t *testing.T) {
+	tests := []struct {
+		name           string
+		urlValues      url.Values
+		want           []string
+		wantErr        bool
+		wantErrField   string
+		wantErrMessage string
+	}{
+		{
+			name:      "include",
+			urlValues: url.Values{"include": []string{"author, comments.user"}},
+			want:      []string{"author", "comments.user"},
+		},
+		{
+			name:      "expand alias",
+			urlValues: url.Values{"expand": []string{"comments"}},
+			want:      []string{"comments"},
+		},
+		{
+			name:      "include and expand are merged",
+			urlValues: url.Values{"include": []string{"author,comments"}, "expand": []string{"comments.user,author"}},
+			want:      []string{"author", "comments", "comments.user"},
+		},
+		{
+			name:           "unknown expanded relation next to include",
+			urlValues:      url.Values{"include": []string{"author"}, "expand": []string{"tags"}},
+			wantErr:        true,
+			wantErrField:   "expand",
+			wantErrMessage: `Unknown relation "tags"`,
+		},
+		{
+			name:           "unknown relation",
+			urlValues:      url.Values{"include": []string{"author.company"}},
+			wantErr:        true,
+			wantErrField:   "include",
+			wantErrMessage: `Unknown relation "author.company"`,
+		},
+		{
+			name:           "too deep",
+			urlValues:      url.Values{"expand": []string{"comments.user.avatar"}},
+			wantErr:        true,
+			wantErrField:   "expand",
+			wantErrMessage: `Relation "comments.user.avatar" is nested deeper than 2 levels`,
+		},
+		{
+			name:           "empty segment",
+			urlValues:      url.Values{"include": []string{"comments..user"}},
+			wantErr:        true,
+			wantErrField:   "include",
+			wantErrMessage: `Invalid relation path "comments..user"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseURLValues(tt.urlValues, WithIncludes(2, "author", "comments.user.avatar"))
+
+			if tt.wantErr {
+				response := NewStandardErrorResponse(http.StatusBadRequest).AddError(err)
+				assert.Equal(t, tt.wantErrField, response.Errors[0]["field"])
+				assert.Equal(t, tt.wantErrMessage, response.Errors[0]["message"])
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Include)
+			assert.Empty(t, got.DynamicFields)
+		})
+	}
+}
+
+func TestFilterOptions_Includes(t *testing.T) {
+	filter := &FilterOptions{Include: []string{"author", "comments.user"}}
+
+	assert.True(t, filter.Includes("author"))
+	assert.True(t, filter.Includes("comments"))
+	assert.True(t, filter.Includes("comments.user"))
+	assert.False(t, filter.Includes("comments.user.avatar"))
+	assert.False(t, filter.Includes("tags"))
+	assert.Equal(t, IncludeTree{"author": {}, "comments": {"user": {}}}, filter.IncludeTree())
+}
+
+func TestAddIncluded(t *testing.T) {
+	filter := &FilterOptions{Page: 1, Limit: 10}
+	paginated := GeneratePaginatedResponse([]int{1}, 1, filter).
+		AddIncluded("authors", []string{"jane"})
+	assert.Equal(t, map[string]interface{}{"authors": []string{"jane"}}, paginated.Included)
+
+	single := GenerateSingleDataResponse(1, "", 0).AddIncluded("author", "jane")
+	raw, err := json.Marshal(single)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"code":200,"message":"Success","data":1,"included":{"author":"jane"}}`, string(raw))
+}
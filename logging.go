@@ -0,0 +1,122 @@
+package goresponse
+
+import (
+	"errors"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// redactedValue replaces the logged values of redacted fields
+const redactedValue = "[REDACTED]"
+
+// DefaultRedactFields are the fields redacted from error logs when
+// ErrorHandlerConfig.RedactFields is nil
+var DefaultRedactFields = []string{"password", "token", "secret", "authorization", "api_key", "access_token", "refresh_token"}
+
+// LogLevelForStatus picks the level of an error response log: error for 5xx, warn for
+// other failures and info for requests the client canceled
+func LogLevelForStatus(status int) slog.Level {
+	switch ErrorCategoryForStatus(status) {
+	case ErrorCategoryServer:
+		return slog.LevelError
+	case ErrorCategoryCanceled:
+		return slog.LevelInfo
+	default:
+		return slog.LevelWarn
+	}
+}
+
+// logErrorResponse logs the response the error handler sends for err
+func (config ErrorHandlerConfig) logErrorResponse(c echo.Context, err error, resp *StandardErrorResponse) {
+	logger := config.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	redact := config.redactFields()
+
+	attrs := []slog.Attr{
+		slog.Int("status", resp.Code),
+		slog.String("code", responseErrorCode(resp)),
+		slog.String("category", string(resp.Category())),
+		slog.String("request_id", requestID(c)),
+		slog.String("method", c.Request().Method),
+		slog.String("route", c.Path()),
+		slog.String("uri", redactURI(c.Request().URL, redact)),
+		slog.String("error", err.Error()),
+		slog.Any("chain", errorChain(err)),
+		slog.Any("errors", redactEntries(resp.Errors, redact)),
+	}
+	if resp.ReferenceID != "" {
+		attrs = append(attrs, slog.String("reference_id", resp.ReferenceID))
+	}
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		attrs = append(attrs, slog.String("stack", string(panicErr.Stack)))
+	}
+	if start, ok := RequestStartFromContext(c.Request().Context()); ok {
+		attrs = append(attrs, slog.Duration("latency", time.Since(start)))
+	}
+	logger.LogAttrs(c.Request().Context(), LogLevelForStatus(resp.Code), "error response", attrs...)
+}
+
+func (config ErrorHandlerConfig) redactFields() []string {
+	if config.RedactFields == nil {
+		return DefaultRedactFields
+	}
+	return config.RedactFields
+}
+
+// responseErrorCode returns the code of the first entry, or the status's code
+func responseErrorCode(resp *StandardErrorResponse) string {
+	if len(resp.Errors) > 0 {
+		return resp.Errors[0]["code"]
+	}
+	return statusErrorCode(resp.Code)
+}
+
+// redactURI returns the request URI with the values of redacted query parameters
+// replaced
+func redactURI(u *url.URL, redact []string) string {
+	query := u.Query()
+	for key := range query {
+		if isRedacted(key, redact) {
+			query.Set(key, redactedValue)
+		}
+	}
+	redacted := *u
+	redacted.RawQuery = query.Encode()
+	return redacted.RequestURI()
+}
+
+// redactEntries copies entries with the messages of redacted fields replaced
+func redactEntries(entries []map[string]string, redact []string) []map[string]string {
+	redacted := make([]map[string]string, len(entries))
+	for i, entry := range entries {
+		redacted[i] = make(map[string]string, len(entry))
+		for key, value := range entry {
+			redacted[i][key] = value
+		}
+		if isRedacted(entry["field"], redact) {
+			redacted[i]["message"] = redactedValue
+		}
+	}
+	return redacted
+}
+
+// isRedacted reports whether the last segment of a field path, e.g. user.password, is
+// in the list, ignoring case
+func isRedacted(field string, redact []string) bool {
+	if i := strings.LastIndex(field, "."); i >= 0 {
+		field = field[i+1:]
+	}
+	for _, name := range redact {
+		if strings.EqualFold(field, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package goresponse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorHandlerLogging(t *testing.T) {
+
+	tests := []struct {
+		name      string
+		err       error
+		redact    []string
+		wantLevel string
+		wantCode  string
+		wantURI   string
+		wantRefID bool
+	}{
+		{
+			name:      "server error",
+			err:       fmt.Errorf("load orders: %w", errors.New("connection reset")),
+			wantLevel: "ERROR",
+			wantCode:  ErrCodeInternal,
+			wantURI:   "/orders/7?page=2&token=%5BREDACTED%5D",
+			wantRefID: true,
+		},
+		{
+			name:      "client error",
+			err:       Validation(FieldDetail{Field: "user.password", Message: "hunter2 is too weak"}),
+			wantLevel: "WARN",
+			wantCode:  ErrCodeValidation,
+			wantURI:   "/orders/7?page=2&token=%5BREDACTED%5D",
+		},
+		{
+			name:      "canceled request with custom redaction",
+			err:       context.Canceled,
+			redact:    []string{"page"},
+			wantLevel: "INFO",
+			wantCode:  ErrCodeRequestCanceled,
+			wantURI:   "/orders/7?page=%5BREDACTED%5D&token=abc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			e := echo.New()
+			e.Use(NewRequestIDMiddleware(RequestIDConfig{Generator: func() string { return "req-1" }}))
+			e.GET("/orders/:id", func(echo.Context) error { return tt.err })
+			e.HTTPErrorHandler = NewErrorHandler(ErrorHandlerConfig{
+				Logger:       slog.New(slog.NewJSONHandler(&logs, nil)),
+				RedactFields: tt.redact,
+			})
+
+			e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orders/7?page=2&token=abc", nil))
+
+			// A single entry, internal errors aren't logged again
+			var entry map[string]interface{}
+			assert.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+			assert.Equal(t, tt.wantLevel, entry["level"])
+			assert.Equal(t, "error response", entry["msg"])
+			assert.Equal(t, tt.wantCode, entry["code"])
+			assert.Equal(t, "req-1", entry["request_id"])
+			assert.Equal(t, "/orders/:id", entry["route"])
+			assert.Equal(t, tt.wantURI, entry["uri"])
+			assert.Equal(t, tt.err.Error(), entry["error"])
+			assert.NotEmpty(t, entry["chain"])
+			assert.Contains(t, entry, "latency")
+			assert.Equal(t, tt.wantRefID, entry["reference_id"] != nil)
+			assert.NotContains(t, logs.String(), "hunter2")
+		})
+	}
+}
+
+func TestErrorHandlerWithoutLogger(t *testing.T) {
+	logs := captureDefaultLogs(t)
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+	NewErrorHandler(ErrorHandlerConfig{})(NotFound("user", 7), c)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, logs.String(), `"msg":"error response"`)
+}
+
+// captureDefaultLogs points slog.Default() at a JSON buffer for the test
+func captureDefaultLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &logs
+}
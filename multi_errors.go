@@ -0,0 +1,85 @@
+package goresponse
+
+import "net/http"
+
+// statusPrecedence ranks the 4xx statuses of joined errors, unlisted ones rank between
+// 400 and 422 and every 5xx outranks them all
+var statusPrecedence = map[int]int{
+	http.StatusBadRequest:          1,
+	http.StatusUnprocessableEntity: 3,
+	http.StatusConflict:            4,
+	http.StatusNotFound:            5,
+	http.StatusForbidden:           6,
+	http.StatusUnauthorized:        7,
+	StatusClientClosedRequest:      8,
+}
+
+// NewFromError creates a response for err with the status and message of its most
+// severe error. Validation errors alone respond with 422.
+func NewFromError(err error) *StandardErrorResponse {
+	return newFromError(err, "")
+}
+
+// newFromError is NewFromError naming the entries that aren't about a field generalField
+func newFromError(err error, generalField string) *StandardErrorResponse {
+	ser := NewStandardErrorResponse(http.StatusUnprocessableEntity)
+	ser.generalField = generalField
+	return ser.AddError(err)
+}
+
+// raiseStatus sets the status of an added error. The first error replaces the status the
+// response was created with, later ones only a less severe status: 5xx > 401 > 403 >
+// 404 > 409 > 422 > other 4xx > 400. A default Message follows the status.
+func (ser *StandardErrorResponse) raiseStatus(status int) {
+	if !ser.statusSet || statusRank(status) > statusRank(ser.Code) {
+		ser.setStatus(status)
+	}
+	ser.statusSet = true
+}
+
+// setStatus changes Code, and Message along with it while it's the status default
+func (ser *StandardErrorResponse) setStatus(status int) {
+	if status == ser.Code {
+		return
+	}
+	ser.Code = status
+	if ser.renderMessage == nil || ser.Message != ser.renderMessage(ser.localeOrDefault()) {
+		return
+	}
+	ser.renderMessage = func(locale string) string {
+		return statusMessage(locale, status)
+	}
+	ser.Message = ser.renderMessage(ser.localeOrDefault())
+}
+
+func statusRank(status int) int {
+	if status >= http.StatusInternalServerError {
+		return 10
+	}
+	if rank, ok := statusPrecedence[status]; ok {
+		return rank
+	}
+	return 2
+}
+
+// joinedErrors flattens errors joined with errors.Join or wrapped with several %w, also
+// below plain wrappers, so each one is added on its own. An HTTPError is never split.
+func joinedErrors(err error) []error {
+	for wrapped := err; wrapped != nil; {
+		switch e := wrapped.(type) {
+		case *HTTPError:
+			return []error{err}
+		case interface{ Unwrap() []error }:
+			var errs []error
+			for _, inner := range e.Unwrap() {
+				errs = append(errs, joinedErrors(inner)...)
+			}
+			return errs
+		case interface{ Unwrap() error }:
+			wrapped = e.Unwrap()
+		default:
+			return []error{err}
+		}
+	}
+	return []error{err}
+}
@@ -0,0 +1,120 @@
+package goresponse
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddJoinedErrors(t *testing.T) {
+
+	required := validator.ValidationErrors{MockValidationError{FieldValue: "Email", TagValue: "required"}}
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantFields []string
+	}{
+		{
+			name:       "validation and conflict",
+			err:        errors.Join(required, Conflict("Slug taken")),
+			wantStatus: http.StatusConflict,
+			wantFields: []string{"email", "error"},
+		},
+		{
+			name:       "not found outranks conflicts",
+			err:        fmt.Errorf("import rows: %w", errors.Join(Conflict("Row 1 exists"), sql.ErrNoRows, Conflict("Row 2 exists"))),
+			wantStatus: http.StatusNotFound,
+			wantFields: []string{"error", "database", "error"},
+		},
+		{
+			name:       "nested joins with a duplicate",
+			err:        errors.Join(required, errors.Join(&QueryParamError{Param: "sort", Message: "Unknown sort"}, required)),
+			wantStatus: http.StatusUnprocessableEntity,
+			wantFields: []string{"email", "sort"},
+		},
+		{
+			name: "unknown error outranks the rest",
+			err: errors.Join(errors.New("rollback failed"), &MySQLError{Number: 1452, Message: "Cannot add or update a child row: " +
+				"a foreign key constraint fails (`shop`.`orders`, CONSTRAINT `orders_user_fk` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`))"}),
+			wantStatus: http.StatusInternalServerError,
+			wantFields: []string{"orders_user_fk", "general"},
+		},
+		{
+			name:       "HTTPError wrapping joined causes",
+			err:        Forbidden("").Wrap(errors.Join(required, sql.ErrNoRows)),
+			wantStatus: http.StatusForbidden,
+			wantFields: []string{"error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := NewStandardErrorResponse(http.StatusBadRequest).AddError(tt.err)
+			fields := make([]string, len(response.Errors))
+			for i, entry := range response.Errors {
+				fields[i] = entry["field"]
+			}
+			assert.Equal(t, tt.wantStatus, response.Code)
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
+
+func TestStatusPrecedenceAcrossAddError(t *testing.T) {
+	response := NewStandardErrorResponse(http.StatusInternalServerError).
+		AddError(&QueryParamError{Param: "limit", Message: "Limit must be a number"}).
+		AddError(NotFound("user", 7)).
+		AddError(&QueryParamError{Param: "offset", Message: "Offset must be a number"})
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+	assert.Equal(t, getDefaultMessageForStatus(http.StatusNotFound), response.Message)
+	assert.Len(t, response.Errors, 3)
+
+	validation := NewStandardErrorResponse(http.StatusBadRequest).
+		AddError(validator.ValidationErrors{MockValidationError{FieldValue: "Email", TagValue: "required"}})
+	assert.Equal(t, http.StatusUnprocessableEntity, validation.Code)
+	assert.Equal(t, getDefaultMessageForStatus(http.StatusUnprocessableEntity), validation.Message)
+
+	custom := NewStandardErrorResponse(http.StatusBadRequest)
+	custom.Message = "Check the form"
+	custom.AddError(NotFound("user", 7))
+	assert.Equal(t, "Check the form", custom.Message, "Custom messages are kept")
+}
+
+func TestNewFromError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:        "validation only",
+			err:         validator.ValidationErrors{MockValidationError{FieldValue: "Email", TagValue: "required"}},
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantMessage: "The submitted data failed validation",
+		},
+		{
+			name:        "joined not found",
+			err:         errors.Join(&QueryParamError{Param: "sort", Message: "Unknown sort"}, NotFound("user", 7)),
+			wantStatus:  http.StatusNotFound,
+			wantMessage: "The requested resource couldn't be found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := NewFromError(tt.err)
+			assert.Equal(t, tt.wantStatus, response.Code)
+			assert.Equal(t, tt.wantMessage, response.Message)
+			assert.Equal(t, statusMessage("id", tt.wantStatus), response.WithLocale("id").Message)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package goresponse
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// negotiatedTypes are the media types Render can send, preferred in this order when the
+// Accept header rates them the same, e.g. JSON for */* and plain text for text/*
+var negotiatedTypes = []string{
+	echo.MIMEApplicationJSON,
+	echo.MIMEApplicationXML,
+	echo.MIMETextPlain,
+	echo.MIMETextHTML,
+	echo.MIMETextXML,
+}
+
+// acceptRange is a media range of an Accept header with its quality
+type acceptRange struct {
+	mediaType string
+	quality   float64
+}
+
+// NegotiateContentType picks the media type Render sends for an Accept header: JSON,
+// XML, plain text or HTML. JSON is picked when the header is empty or lists none of them.
+func NegotiateContentType(accept string) string {
+	return negotiateType(accept, negotiatedTypes)
+}
+
+// negotiateType picks the offer the Accept header rates highest, the first one on ties,
+// JSON when it rates none of them
+func negotiateType(accept string, offers []string) string {
+	ranges := parseAccept(accept)
+	best, bestQuality := echo.MIMEApplicationJSON, 0.0
+	for _, offer := range offers {
+		if quality := acceptQuality(ranges, offer); quality > bestQuality {
+			best, bestQuality = offer, quality
+		}
+	}
+	return best
+}
+
+// parseAccept reads the media ranges of an Accept header, skipping invalid ones
+func parseAccept(accept string) []acceptRange {
+	var ranges []acceptRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil {
+			quality = q
+		}
+		ranges = append(ranges, acceptRange{mediaType: mediaType, quality: quality})
+	}
+	return ranges
+}
+
+// acceptQuality returns the quality of the most specific range matching mediaType, 0
+// when none does
+func acceptQuality(ranges []acceptRange, mediaType string) float64 {
+	mainType, _, _ := strings.Cut(mediaType, "/")
+	quality, specificity := 0.0, 0
+	for _, r := range ranges {
+		var matched int
+		switch r.mediaType {
+		case mediaType:
+			matched = 3
+		case mainType + "/*":
+			matched = 2
+		case "*/*":
+			matched = 1
+		}
+		if matched > specificity {
+			quality, specificity = r.quality, matched
+		}
+	}
+	return quality
+}
+
+// Render sends the response in the format negotiated from the Accept header, see
+// NegotiateContentType. With ErrorFormatNegotiate problem+json is ranked along with the
+// other formats, with ErrorFormatProblem it's sent instead of JSON.
+func (ser *StandardErrorResponse) Render(c echo.Context) error {
+	offers := negotiatedTypes
+	if ser.format == ErrorFormatNegotiate {
+		offers = append([]string{echo.MIMEApplicationJSON, MIMEApplicationProblemJSON}, negotiatedTypes[1:]...)
+	}
+	mediaType := negotiateType(c.Request().Header.Get(echo.HeaderAccept), offers)
+	switch mediaType {
+	case echo.MIMEApplicationJSON, MIMEApplicationProblemJSON:
+		return ser.sendJSON(c, mediaType == MIMEApplicationProblemJSON || ser.format == ErrorFormatProblem)
+	}
+
+	ser.prepare(c)
+	page := &ResponsePage{
+		Status:      ser.Code,
+		Title:       statusText(ser.Code),
+		Message:     ser.Message,
+		Errors:      ser.Errors,
+		RequestID:   ser.RequestID,
+		ReferenceID: ser.ReferenceID,
+		Lang:        ser.localeOrDefault(),
+		Response:    ser,
+	}
+	return renderNegotiated(c, mediaType, page, errorPageTemplate())
+}
+
+// Render sends the response in the format negotiated from the Accept header, see
+// NegotiateContentType
+func (r *SingleDataResponse) Render(c echo.Context) error {
+	r.RequestID = requestID(c)
+	data, err := projectData(r.Data, r.fieldset)
+	if err != nil {
+		return err
+	}
+	page := &ResponsePage{
+		Status:    r.Code,
+		Title:     statusText(r.Code),
+		Message:   r.Message,
+		Data:      indentedJSON(data),
+		RequestID: r.RequestID,
+		Lang:      ResolveLocale(c),
+		Response:  r,
+	}
+	return renderNegotiated(c, NegotiateContentType(c.Request().Header.Get(echo.HeaderAccept)), page, dataPageTemplate())
+}
+
+// Render sends the page with a 200 status in the format negotiated from the Accept
+// header, see NegotiateContentType
+func (r *PaginatedResponse) Render(c echo.Context) error {
+	r.RequestID = requestID(c)
+	data, err := projectData(r.Data, r.fieldset)
+	if err != nil {
+		return err
+	}
+	page := &ResponsePage{
+		Status:      http.StatusOK,
+		Title:       statusText(http.StatusOK),
+		Data:        indentedJSON(data),
+		CurrentPage: r.CurrentPage,
+		TotalPage:   r.TotalPage,
+		TotalData:   r.TotalData,
+		RequestID:   r.RequestID,
+		Lang:        ResolveLocale(c),
+		Response:    r,
+	}
+	return renderNegotiated(c, NegotiateContentType(c.Request().Header.Get(echo.HeaderAccept)), page, dataPageTemplate())
+}
+
+// renderNegotiated sends page as the negotiated mediaType
+func renderNegotiated(c echo.Context, mediaType string, page *ResponsePage, tmpl *template.Template) error {
+	switch mediaType {
+	case echo.MIMETextPlain:
+		return c.String(page.Status, page.Text())
+	case echo.MIMETextHTML:
+		return renderHTML(c, page, tmpl)
+	case echo.MIMEApplicationXML, echo.MIMETextXML:
+		return c.XML(page.Status, page.Response)
+	default:
+		return c.JSON(page.Status, page.Response)
+	}
+}
+
+// Text renders the page as plain text
+func (p *ResponsePage) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d %s\n", p.Status, p.Title)
+	if p.Message != "" && p.Message != p.Title {
+		fmt.Fprintf(&b, "%s\n", p.Message)
+	}
+	p.writeTextBody(&b)
+	if p.RequestID != "" || p.ReferenceID != "" {
+		b.WriteString("\n")
+	}
+	if p.RequestID != "" {
+		fmt.Fprintf(&b, "Request ID: %s\n", p.RequestID)
+	}
+	if p.ReferenceID != "" {
+		fmt.Fprintf(&b, "Reference ID: %s\n", p.ReferenceID)
+	}
+	return b.String()
+}
+
+// writeTextBody writes the error entries, or the pagination and data of the page
+func (p *ResponsePage) writeTextBody(b *strings.Builder) {
+	if len(p.Errors) > 0 {
+		b.WriteString("\n")
+	}
+	for _, entry := range p.Errors {
+		fmt.Fprintf(b, "%s: %s (%s)\n", entry["field"], entry["message"], entry["code"])
+	}
+	if p.TotalPage > 0 {
+		fmt.Fprintf(b, "\nPage %d of %d, %d in total\n", p.CurrentPage, p.TotalPage, p.TotalData)
+	}
+	if p.Data != "" {
+		fmt.Fprintf(b, "\n%s\n", p.Data)
+	}
+}
+
+// indentedJSON renders data for the plain text and HTML formats, "" when it's nil
+func indentedJSON(data interface{}) string {
+	if data == nil {
+		return ""
+	}
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%v", data)
+	}
+	return string(raw)
+}
@@ -0,0 +1,208 @@
+package goresponse
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateContentType(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		want   string
+	}{
+		{name: "no header", accept: "", want: echo.MIMEApplicationJSON},
+		{name: "anything", accept: "*/*", want: echo.MIMEApplicationJSON},
+		{name: "xml", accept: "application/xml", want: echo.MIMEApplicationXML},
+		{name: "text xml", accept: "text/xml", want: echo.MIMETextXML},
+		{name: "any text", accept: "text/*", want: echo.MIMETextPlain},
+		{name: "browser", accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", want: echo.MIMETextHTML},
+		{name: "quality", accept: "application/json;q=0.5, text/plain", want: echo.MIMETextPlain},
+		{name: "excluded by a more specific range", accept: "application/json;q=0, */*", want: echo.MIMEApplicationXML},
+		{name: "nothing supported", accept: "image/png", want: echo.MIMEApplicationJSON},
+		{name: "invalid", accept: "not a media type", want: echo.MIMEApplicationJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NegotiateContentType(tt.accept))
+		})
+	}
+}
+
+func newNegotiationEcho() *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = NewErrorHandler(ErrorHandlerConfig{ContentNegotiation: true})
+	e.Use(NewRequestIDMiddleware(RequestIDConfig{Generator: func() string { return "req-1" }}))
+	e.GET("/users/:id", func(c echo.Context) error {
+		if c.Param("id") != "1" {
+			return NotFound("user", c.Param("id"))
+		}
+		return GenerateSingleDataResponse(map[string]interface{}{"id": 1, "name": "<ann>"}, "", http.StatusOK).Render(c)
+	})
+	e.GET("/users", func(c echo.Context) error {
+		filter := (&FilterOptions{Page: 2, Limit: 1}).Validate()
+		return GeneratePaginatedResponse([]map[string]interface{}{{"id": 2}}, 3, filter).Render(c)
+	})
+	return e
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name            string
+		target          string
+		accept          string
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "error as json",
+			target:          "/users/7",
+			wantStatus:      http.StatusNotFound,
+			wantContentType: echo.MIMEApplicationJSON,
+			wantBody:        `{"code":404,"message":"The requested resource couldn't be found","errors":[{"code":"RESOURCE_NOT_FOUND","field":"error","message":"We couldn't find user 7"}],"request_id":"req-1"}` + "\n",
+		},
+		{
+			name:            "error as xml",
+			target:          "/users/7",
+			accept:          echo.MIMEApplicationXML,
+			wantStatus:      http.StatusNotFound,
+			wantContentType: echo.MIMEApplicationXMLCharsetUTF8,
+			wantBody: `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+				`<response><code>404</code><message>The requested resource couldn&#39;t be found</message>` +
+				`<errors><item><code>RESOURCE_NOT_FOUND</code><field>error</field><message>We couldn&#39;t find user 7</message></item></errors>` +
+				`<request_id>req-1</request_id></response>`,
+		},
+		{
+			name:            "error as text",
+			target:          "/users/7",
+			accept:          echo.MIMETextPlain,
+			wantStatus:      http.StatusNotFound,
+			wantContentType: echo.MIMETextPlainCharsetUTF8,
+			wantBody: "404 Not Found\nThe requested resource couldn't be found\n\n" +
+				"error: We couldn't find user 7 (RESOURCE_NOT_FOUND)\n\nRequest ID: req-1\n",
+		},
+		{
+			name:            "single as xml",
+			target:          "/users/1",
+			accept:          echo.MIMETextXML,
+			wantStatus:      http.StatusOK,
+			wantContentType: echo.MIMEApplicationXMLCharsetUTF8,
+			wantBody: `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+				`<response><code>200</code><message>Success</message><data><id>1</id><name>&lt;ann&gt;</name></data><request_id>req-1</request_id></response>`,
+		},
+		{
+			name:            "single as text",
+			target:          "/users/1",
+			accept:          echo.MIMETextPlain,
+			wantStatus:      http.StatusOK,
+			wantContentType: echo.MIMETextPlainCharsetUTF8,
+			wantBody:        "200 OK\nSuccess\n\n{\n  \"id\": 1,\n  \"name\": \"\\u003cann\\u003e\"\n}\n\nRequest ID: req-1\n",
+		},
+		{
+			name:            "page as text",
+			target:          "/users",
+			accept:          echo.MIMETextPlain,
+			wantStatus:      http.StatusOK,
+			wantContentType: echo.MIMETextPlainCharsetUTF8,
+			wantBody:        "200 OK\n\nPage 2 of 3, 3 in total\n\n[\n  {\n    \"id\": 2\n  }\n]\n\nRequest ID: req-1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			req.Header.Set(echo.HeaderAccept, tt.accept)
+			rec := httptest.NewRecorder()
+			newNegotiationEcho().ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantContentType, rec.Header().Get(echo.HeaderContentType))
+			assert.Equal(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	e := newNegotiationEcho()
+	serve := func(target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set(echo.HeaderAccept, "text/html,application/xml;q=0.9,*/*;q=0.8")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("/users/<7>")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, echo.MIMETextHTMLCharsetUTF8, rec.Header().Get(echo.HeaderContentType))
+	assert.Contains(t, rec.Body.String(), `<html lang="en">`)
+	assert.Contains(t, rec.Body.String(), "<title>404 Not Found</title>")
+	assert.Contains(t, rec.Body.String(), "<li><strong>error</strong>: We couldn&#39;t find user &lt;7&gt; <code>RESOURCE_NOT_FOUND</code></li>")
+	assert.Contains(t, rec.Body.String(), "Request ID: req-1")
+
+	rec = serve("/users")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "<p>Page 2 of 3, 3 in total</p>")
+
+	SetErrorPageTemplate(template.Must(template.New("error").Parse(`<p>{{.Status}}: {{.Message}}</p>`)))
+	SetDataPageTemplate(template.Must(template.New("data").Parse(`<p>{{.TotalData}} users</p>`)))
+	t.Cleanup(func() {
+		SetErrorPageTemplate(nil)
+		SetDataPageTemplate(nil)
+	})
+	assert.Equal(t, "<p>404: The requested resource couldn&#39;t be found</p>", serve("/users/7").Body.String())
+	assert.Equal(t, "<p>3 users</p>", serve("/users").Body.String())
+}
+
+func TestRenderProblemNegotiation(t *testing.T) {
+	tests := []struct {
+		name            string
+		format          ErrorFormat
+		accept          string
+		wantContentType string
+	}{
+		{name: "problem+json preferred", format: ErrorFormatNegotiate, accept: "application/problem+json, text/html;q=0.5", wantContentType: MIMEApplicationProblemJSON},
+		{name: "html preferred", format: ErrorFormatNegotiate, accept: "application/problem+json;q=0.5, text/html", wantContentType: echo.MIMETextHTMLCharsetUTF8},
+		{name: "json for anything", format: ErrorFormatNegotiate, accept: "*/*", wantContentType: echo.MIMEApplicationJSON},
+		{name: "problem instead of json", format: ErrorFormatProblem, accept: "application/json", wantContentType: MIMEApplicationProblemJSON},
+		{name: "not offered by the standard format", format: ErrorFormatStandard, accept: "application/problem+json, text/html;q=0.5", wantContentType: echo.MIMETextHTMLCharsetUTF8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.HTTPErrorHandler = NewErrorHandler(ErrorHandlerConfig{Format: tt.format, ContentNegotiation: true})
+			e.GET("/", func(echo.Context) error { return NotFound("user", 7) })
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echo.HeaderAccept, tt.accept)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusNotFound, rec.Code)
+			assert.Equal(t, tt.wantContentType, rec.Header().Get(echo.HeaderContentType))
+		})
+	}
+}
+
+func TestRecoverContentNegotiation(t *testing.T) {
+
+	handler := NewRecoverHandler(RecoverConfig{ErrorHandler: ErrorHandlerConfig{ContentNegotiation: true}})(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(echo.HeaderAccept, echo.MIMETextPlain)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, echo.MIMETextPlainCharsetUTF8, rec.Header().Get(echo.HeaderContentType))
+	assert.Contains(t, rec.Body.String(), "500 Internal Server Error\n")
+	assert.Contains(t, rec.Body.String(), "Reference ID: ")
+}
@@ -0,0 +1,167 @@
+package goresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+)
+
+type (
+	// PageFetcher loads the page described by filter
+	PageFetcher func(ctx context.Context, filter *FilterOptions) (*PaginatedResponse, error)
+	// PageOption configures how Pages and AllPages walk through a dataset
+	PageOption func(*pageConfig)
+
+	pageConfig struct {
+		prefetch bool
+	}
+	pageResult struct {
+		resp *PaginatedResponse
+		err  error
+	}
+)
+
+// WithPrefetch fetches the next page concurrently while the current one is consumed
+func WithPrefetch() PageOption {
+	return func(cfg *pageConfig) {
+		cfg.prefetch = true
+	}
+}
+
+// Pages walks every page of a dataset starting at filter, which is left untouched, or
+// at the first page of a FilterOptions with the Validate defaults when filter is nil.
+// Pages are advanced with NextCursor when the response sets one, otherwise with Page
+// and Offset until TotalPage is reached or a short page is returned. Iteration stops
+// after the first error, which is yielded, including context cancellation.
+func Pages(ctx context.Context, filter *FilterOptions, fetch PageFetcher, opts ...PageOption) iter.Seq2[*PaginatedResponse, error] {
+	cfg := &pageConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(yield func(*PaginatedResponse, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		first := FilterOptions{}
+		if filter != nil {
+			first = *filter
+		}
+		current := first.Validate()
+		pending := startPageFetch(ctx, fetch, current, false)
+		for {
+			result := pending()
+			if result.err != nil {
+				yield(nil, result.err)
+				return
+			}
+
+			next, more := nextPageFilter(current, result.resp)
+			if more {
+				pending = startPageFetch(ctx, fetch, next, cfg.prefetch)
+			}
+			if !yield(result.resp, nil) || !more {
+				return
+			}
+			current = next
+		}
+	}
+}
+
+// AllPages walks every page like Pages and yields the items of each page's Data,
+// which must be a []T
+func AllPages[T any](ctx context.Context, filter *FilterOptions, fetch PageFetcher, opts ...PageOption) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for resp, err := range Pages(ctx, filter, fetch, opts...) {
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			items, err := pageItems[T](resp)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// pageItems returns the Data of a page as []T
+func pageItems[T any](resp *PaginatedResponse) ([]T, error) {
+	items, ok := resp.Data.([]T)
+	if !ok && resp.Data != nil {
+		return nil, fmt.Errorf("page data is %T, expected %T", resp.Data, items)
+	}
+	return items, nil
+}
+
+// startPageFetch starts loading a page, in the background when async is set, and
+// returns a function that waits for the result
+func startPageFetch(ctx context.Context, fetch PageFetcher, filter *FilterOptions, async bool) func() pageResult {
+	if !async {
+		return func() pageResult {
+			return fetchPage(ctx, fetch, filter)
+		}
+	}
+
+	ch := make(chan pageResult, 1)
+	go func() {
+		ch <- fetchPage(ctx, fetch, filter)
+	}()
+	return func() pageResult {
+		return <-ch
+	}
+}
+
+func fetchPage(ctx context.Context, fetch PageFetcher, filter *FilterOptions) pageResult {
+	if err := ctx.Err(); err != nil {
+		return pageResult{err: err}
+	}
+	resp, err := fetch(ctx, filter)
+	if err == nil && resp == nil {
+		err = errors.New("page fetcher returned no response")
+	}
+	return pageResult{resp: resp, err: err}
+}
+
+// nextPageFilter returns the filter of the page after resp, or false when it was the last
+func nextPageFilter(current *FilterOptions, resp *PaginatedResponse) (*FilterOptions, bool) {
+	next := *current
+	if resp.NextCursor != "" && resp.NextCursor != current.Cursor {
+		next.Cursor = resp.NextCursor
+		return &next, true
+	}
+	if current.Cursor != "" || !hasMorePages(current, resp) {
+		return nil, false
+	}
+
+	offset := current.Limit
+	if current.Offset != nil {
+		offset += *current.Offset
+	}
+	next.Page++
+	next.Offset = &offset
+	return &next, true
+}
+
+func hasMorePages(current *FilterOptions, resp *PaginatedResponse) bool {
+	if resp.TotalPage > 0 {
+		return current.Page < resp.TotalPage
+	}
+
+	// Without totals a full page means there may be more
+	data := reflect.ValueOf(resp.Data)
+	if data.Kind() != reflect.Slice {
+		return false
+	}
+	return data.Len() > 0 && data.Len() >= current.Limit
+}
@@ -0,0 +1,152 @@
+package goresponse
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// offsetFetcher serves items from a slice using Offset and Limit
+func offsetFetcher(items []int, calls *int32) PageFetcher {
+	return func(_ context.Context, filter *FilterOptions) (*PaginatedResponse, error) {
+		atomic.AddInt32(calls, 1)
+		start := min(*filter.Offset, len(items))
+		end := min(start+filter.Limit, len(items))
+		return GeneratePaginatedResponse(items[start:end], len(items), filter), nil
+	}
+}
+
+func testItems(n int) []int {
+	items := make([]int, n)
+	for i := range items {
+		items[i] = i
+	}
+	return items
+}
+
+func collect[T any](t *testing.T, seq func(func(T, error) bool)) ([]T, error) {
+	t.Helper()
+	var got []T
+	for item, err := range seq {
+		if err != nil {
+			return got, err
+		}
+		got = append(got, item)
+	}
+	return got, nil
+}
+
+func TestAllPages(t *testing.T) {
+	items := testItems(25)
+
+	for _, opts := range [][]PageOption{nil, {WithPrefetch()}} {
+		var calls int32
+		filter := &FilterOptions{Page: 1, Limit: 10}
+
+		got, err := collect(t, AllPages[int](context.Background(), filter, offsetFetcher(items, &calls), opts...))
+		assert.NoError(t, err)
+		assert.Equal(t, items, got)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+		assert.Equal(t, 1, filter.Page, "Caller's filter should not be modified")
+		assert.Nil(t, filter.Offset)
+	}
+}
+
+func TestAllPagesNilFilter(t *testing.T) {
+	items := testItems(25)
+	var calls int32
+
+	got, err := collect(t, AllPages[int](context.Background(), nil, offsetFetcher(items, &calls)))
+	assert.NoError(t, err)
+	assert.Equal(t, items, got)
+}
+
+func TestAllPagesWithoutTotals(t *testing.T) {
+	items := testItems(20)
+	var calls int32
+	fetch := func(ctx context.Context, filter *FilterOptions) (*PaginatedResponse, error) {
+		resp, err := offsetFetcher(items, &calls)(ctx, filter)
+		resp.TotalData, resp.TotalPage = 0, 0
+		return resp, err
+	}
+
+	got, err := collect(t, AllPages[int](context.Background(), &FilterOptions{Page: 1, Limit: 10}, fetch))
+	assert.NoError(t, err)
+	assert.Equal(t, items, got)
+	assert.Equal(t, int32(3), calls, "A short (empty) page ends the iteration")
+}
+
+func TestAllPagesCursor(t *testing.T) {
+	var cursors []string
+	fetch := func(_ context.Context, filter *FilterOptions) (*PaginatedResponse, error) {
+		cursors = append(cursors, filter.Cursor)
+		page, _ := strconv.Atoi(filter.Cursor)
+		resp := &PaginatedResponse{Data: []int{page}}
+		if page < 2 {
+			resp.NextCursor = strconv.Itoa(page + 1)
+		}
+		return resp, nil
+	}
+
+	got, err := collect(t, AllPages[int](context.Background(), &FilterOptions{Cursor: "0"}, fetch))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2}, got)
+	assert.Equal(t, []string{"0", "1", "2"}, cursors)
+}
+
+func TestAllPagesStops(t *testing.T) {
+	items := testItems(50)
+
+	t.Run("consumer break", func(t *testing.T) {
+		var calls int32
+		for item := range AllPages[int](context.Background(), &FilterOptions{Page: 1, Limit: 10}, offsetFetcher(items, &calls)) {
+			if item == 12 {
+				break
+			}
+		}
+		assert.Equal(t, int32(2), calls)
+	})
+
+	t.Run("fetch error", func(t *testing.T) {
+		fetchErr := errors.New("database is down")
+		calls := 0
+		fetch := func(ctx context.Context, filter *FilterOptions) (*PaginatedResponse, error) {
+			if calls++; calls == 2 {
+				return nil, fetchErr
+			}
+			return GeneratePaginatedResponse([]int{1}, 50, filter), nil
+		}
+
+		got, err := collect(t, AllPages[int](context.Background(), &FilterOptions{Page: 1, Limit: 1}, fetch))
+		assert.ErrorIs(t, err, fetchErr)
+		assert.Equal(t, []int{1}, got)
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var calls int32
+		var got []int
+		var err error
+		for item, itemErr := range AllPages[int](ctx, &FilterOptions{Page: 1, Limit: 10}, offsetFetcher(items, &calls)) {
+			if itemErr != nil {
+				err = itemErr
+				break
+			}
+			got = append(got, item)
+			cancel()
+		}
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Len(t, got, 10)
+	})
+
+	t.Run("wrong data type", func(t *testing.T) {
+		var calls int32
+		_, err := collect(t, AllPages[string](context.Background(), &FilterOptions{Page: 1, Limit: 10}, offsetFetcher(items, &calls)))
+		assert.EqualError(t, err, "page data is []int, expected []string")
+	})
+}
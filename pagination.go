@@ -3,6 +3,7 @@ package goresponse
 import (
 	"fmt"
 	"math"
+	"net/http"
 	"net/url"
 	"os"
 	"reflect"
@@ -17,26 +18,38 @@ import (
 
 type (
 	FilterOptions struct {
-		Page          int                    `param:"page" query:"page" form:"page" json:"page,omitempty" xml:"page,omitempty"`
-		Limit         int                    `param:"limit" query:"limit" form:"limit" json:"limit,omitempty" xml:"limit,omitempty"`
-		Offset        *int                   `param:"offset" query:"offset" form:"offset" json:"offset,omitempty" xml:"offset,omitempty"`
-		Search        string                 `param:"q" query:"q" form:"q" json:"q,omitempty" xml:"q,omitempty"`
-		Dir           string                 `param:"sort" query:"sort" form:"sort" json:"sort,omitempty" xml:"sort,omitempty"`
-		SortBy        string                 `param:"sort_by" query:"sort_by" form:"sort_by" json:"sort_by,omitempty" xml:"sort_by,omitempty"`
-		StartDate     string                 `param:"start_date" query:"start_date" form:"start_date" json:"start_date,omitempty" xml:"start_date,omitempty"`
-		EndDate       string                 `param:"end_date" query:"end_date" form:"end_date" json:"end_date,omitempty" xml:"end_date,omitempty"`
-		Type          string                 `param:"type" query:"type" form:"type" json:"type,omitempty" xml:"type,omitempty"`
-		Status        string                 `param:"status" query:"status" form:"status" json:"status,omitempty" xml:"status,omitempty"`
-		Categories    []string               `param:"categories" query:"categories" form:"categories" json:"categories,omitempty" xml:"categories,omitempty"`
-		DynamicFields map[string]interface{} `json:"-"`
+		Page           int                    `param:"page" query:"page" form:"page" json:"page,omitempty" xml:"page,omitempty"`
+		Limit          int                    `param:"limit" query:"limit" form:"limit" json:"limit,omitempty" xml:"limit,omitempty"`
+		Offset         *int                   `param:"offset" query:"offset" form:"offset" json:"offset,omitempty" xml:"offset,omitempty"`
+		Search         string                 `param:"q" query:"q" form:"q" json:"q,omitempty" xml:"q,omitempty"`
+		Dir            string                 `param:"sort" query:"sort" form:"sort" json:"sort,omitempty" xml:"sort,omitempty"`
+		SortBy         string                 `param:"sort_by" query:"sort_by" form:"sort_by" json:"sort_by,omitempty" xml:"sort_by,omitempty"`
+		StartDate      string                 `param:"start_date" query:"start_date" form:"start_date" json:"start_date,omitempty" xml:"start_date,omitempty"`
+		EndDate        string                 `param:"end_date" query:"end_date" form:"end_date" json:"end_date,omitempty" xml:"end_date,omitempty"`
+		Type           string                 `param:"type" query:"type" form:"type" json:"type,omitempty" xml:"type,omitempty"`
+		Status         string                 `param:"status" query:"status" form:"status" json:"status,omitempty" xml:"status,omitempty"`
+		Categories     []string               `param:"categories" query:"categories" form:"categories" json:"categories,omitempty" xml:"categories,omitempty"`
+		Fields         []string               `param:"fields" query:"fields" form:"fields" json:"fields,omitempty" xml:"fields,omitempty"`
+		Include        []string               `param:"include" query:"include" form:"include" json:"include,omitempty" xml:"include,omitempty"`
+		Facets         []string               `param:"facets" query:"facets" form:"facets" json:"facets,omitempty" xml:"facets,omitempty"`
+		Cursor         string                 `param:"cursor" query:"cursor" form:"cursor" json:"cursor,omitempty" xml:"cursor,omitempty"`
+		ResourceFields map[string][]string    `json:"-"`
+		DynamicFields  map[string]interface{} `json:"-"`
+		SearchQuery    *SearchQuery           `json:"-"`
 	}
 	PaginatedResponse struct {
-		TotalData   int            `json:"total_data,omitempty"`
-		TotalPage   int            `json:"total_page,omitempty"`
-		CurrentPage int            `json:"current_page,omitempty"`
-		PageSize    int            `json:"page_size,omitempty"`
-		Data        interface{}    `json:"data,omitempty"`
-		Filters     *FilterOptions `json:"filters,omitempty"`
+		TotalData   int                    `json:"total_data,omitempty"`
+		TotalPage   int                    `json:"total_page,omitempty"`
+		CurrentPage int                    `json:"current_page,omitempty"`
+		PageSize    int                    `json:"page_size,omitempty"`
+		Data        interface{}            `json:"data,omitempty"`
+		Filters     *FilterOptions         `json:"filters,omitempty"`
+		Included    map[string]interface{} `json:"included,omitempty"`
+		NextCursor  string                 `json:"next_cursor,omitempty"`
+		Facets      map[string]*Facet      `json:"facets,omitempty"`
+		RequestID   string                 `json:"request_id,omitempty"`
+
+		fieldset *FilterOptions // Sparse fieldsets applied to Data when it's marshaled
 	}
 )
 
@@ -90,7 +103,26 @@ func handleKnownFields(filter *FilterOptions, values url.Values, knownParams map
 	return nil
 }
 
-func ParseURLValues(values url.Values) (*FilterOptions, error) {
+// ParseOption configures optional behaviour of ParseURLValues
+type ParseOption func(*parseConfig)
+
+type parseConfig struct {
+	nestedDynamicFields bool
+	allowedFields       map[string]map[string]struct{}
+	// postParse steps run on the validated filter, in the order the options were given
+	postParse []func(filter *FilterOptions, values url.Values) error
+}
+
+func newParseConfig(opts []ParseOption) *parseConfig {
+	cfg := &parseConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+func ParseURLValues(values url.Values, opts ...ParseOption) (*FilterOptions, error) {
+	cfg := newParseConfig(opts)
 	filter := &FilterOptions{
 		DynamicFields: make(map[string]interface{}),
 	}
@@ -101,14 +133,46 @@ func ParseURLValues(values url.Values) (*FilterOptions, error) {
 		return nil, err
 	}
 
-	// Handle dynamic fields
-	for param, paramValues := range values {
-		if _, isKnown := knownParams[param]; !isKnown && len(paramValues) > 0 {
-			filter.DynamicFields[param] = handleDynamicField(paramValues)
+	if err := handleDynamicFields(filter, values, knownParams, cfg); err != nil {
+		return nil, err
+	}
+
+	filter.Validate()
+	for _, step := range cfg.postParse {
+		if err := step(filter, values); err != nil {
+			return nil, err
 		}
 	}
+	return filter, nil
+}
 
-	return filter.Validate(), nil
+func handleDynamicFields(filter *FilterOptions, values url.Values, knownParams map[string]struct{}, cfg *parseConfig) error {
+	// Sort params so conflicting nested paths are reported deterministically
+	params := make([]string, 0, len(values))
+	for param := range values {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+
+	for _, param := range params {
+		paramValues := values[param]
+		if _, isKnown := knownParams[param]; isKnown || len(paramValues) == 0 {
+			continue
+		}
+		if handleResourceFields(filter, param, paramValues) {
+			continue
+		}
+		if cfg.nestedDynamicFields {
+			if segments, ok := splitBracketPath(param); ok {
+				if err := setDynamicPath(filter.DynamicFields, param, segments, paramValues); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		filter.DynamicFields[param] = handleDynamicField(paramValues)
+	}
+	return nil
 }
 
 func handleDynamicUUIDValue(value string) (interface{}, bool) {
@@ -298,49 +362,60 @@ func formatCacheKeyField(fieldName, fieldValue string) string {
 	return fmt.Sprintf("%s:%s", fieldName, fieldValue)
 }
 
-func sortDynamicFields(fields map[string]interface{}) []string {
-	// Get all dynamic field keys
+// dynamicValueStrings converts a dynamic field value into its query string representation
+func dynamicValueStrings(v interface{}) []string {
+	switch v := v.(type) {
+	case uuid.UUID:
+		return []string{v.String()}
+	case []uuid.UUID:
+		strVals := make([]string, len(v))
+		for i, u := range v {
+			strVals[i] = u.String()
+		}
+		return strVals
+	case []string:
+		return append([]string(nil), v...)
+	case []interface{}:
+		strVals := make([]string, len(v))
+		for i, val := range v {
+			strVals[i] = fmt.Sprintf("%v", val)
+		}
+		return strVals
+	default:
+		return []string{fmt.Sprintf("%v", v)}
+	}
+}
+
+// sortedDynamicKeys returns the keys of a dynamic field map in sorted order
+func sortedDynamicKeys(fields map[string]interface{}) []string {
 	keys := make([]string, 0, len(fields))
 	for k := range fields {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
+	return keys
+}
 
-	// Create sorted key-value pairs
-	sorted := make([]string, 0, len(fields))
-	for _, k := range keys {
-		v := fields[k]
-		var value string
-
-		switch v := v.(type) {
-		case uuid.UUID:
-			value = v.String()
-		case []uuid.UUID:
-			uuidStrs := make([]string, len(v))
-			for i, u := range v {
-				uuidStrs[i] = u.String()
-			}
-			sort.Strings(uuidStrs)
-			value = strings.Join(uuidStrs, ",")
-		case []string:
-			sort.Strings(v)
-			value = strings.Join(v, ",")
-		case []interface{}:
-			strVals := make([]string, len(v))
-			for i, val := range v {
-				strVals[i] = fmt.Sprintf("%v", val)
-			}
-			sort.Strings(strVals)
-			value = strings.Join(strVals, ",")
-		default:
-			value = fmt.Sprintf("%v", v)
+func sortDynamicFields(fields map[string]interface{}) []string {
+	return appendSortedDynamicFields(make([]string, 0, len(fields)), "", fields)
+}
+
+// appendSortedDynamicFields appends cache key segments for fields, flattening nested
+// maps into bracket paths so nested and flat filters produce the same key
+func appendSortedDynamicFields(sorted []string, prefix string, fields map[string]interface{}) []string {
+	for _, k := range sortedDynamicKeys(fields) {
+		name := joinBracketPath(prefix, k)
+		if nested, ok := fields[k].(map[string]interface{}); ok {
+			sorted = appendSortedDynamicFields(sorted, name, nested)
+			continue
 		}
 
-		if value != "" {
-			sorted = append(sorted, formatCacheKeyField(k, value))
+		strVals := dynamicValueStrings(fields[k])
+		sort.Strings(strVals)
+		if value := strings.Join(strVals, ","); value != "" {
+			sorted = append(sorted, formatCacheKeyField(name, value))
 		}
 	}
-
 	return sorted
 }
 
@@ -369,6 +444,11 @@ func (filter FilterOptions) GenerateCacheKey(redisKeyPrefix string) string {
 		}
 	}
 
+	// Handle sparse fieldsets of nested resources
+	for param, value := range filter.resourceFieldValues() {
+		sortedFields = append(sortedFields, formatCacheKeyField(param, value[0]))
+	}
+
 	// Handle dynamic fields
 	if len(filter.DynamicFields) > 0 {
 		dynamicFields := sortDynamicFields(filter.DynamicFields)
@@ -382,6 +462,55 @@ func (filter FilterOptions) GenerateCacheKey(redisKeyPrefix string) string {
 	return redisKeyPrefix + "list:" + hashValue
 }
 
+// URLValues encodes the filter back into query parameters understood by ParseURLValues.
+// Slices are sorted and nested dynamic fields are written as bracket paths, so the
+// encoding is deterministic. Dynamic slices that are nested or hold a single value keep
+// their [] suffix, so they parse back as slices.
+func (filter FilterOptions) URLValues() url.Values {
+	values := url.Values{}
+	v := reflect.ValueOf(filter)
+	t := v.Type()
+
+	for i := 0; i < v.NumField(); i++ {
+		queryTag := t.Field(i).Tag.Get("query")
+		if queryTag == "" {
+			continue
+		}
+		if value, hasValue := handleFieldValue(v.Field(i)); hasValue {
+			values.Set(queryTag, value)
+		}
+	}
+
+	for param, value := range filter.resourceFieldValues() {
+		values[param] = value
+	}
+	appendDynamicURLValues(values, "", filter.DynamicFields)
+	return values
+}
+
+func appendDynamicURLValues(values url.Values, prefix string, fields map[string]interface{}) {
+	for _, k := range sortedDynamicKeys(fields) {
+		name := joinBracketPath(prefix, k)
+		if nested, ok := fields[k].(map[string]interface{}); ok {
+			appendDynamicURLValues(values, name, nested)
+			continue
+		}
+		strVals := dynamicValueStrings(fields[k])
+		sort.Strings(strVals)
+		if isListValue(fields[k]) && (prefix != "" || len(strVals) == 1) {
+			name += "[]"
+		}
+		for _, value := range strVals {
+			values.Add(name, value)
+		}
+	}
+}
+
+// isListValue reports whether a dynamic field holds a slice
+func isListValue(v interface{}) bool {
+	return v != nil && reflect.TypeOf(v).Kind() == reflect.Slice
+}
+
 // GetMaxLimitFromEnv reads the maximum limit from environment variables, with a default of 100
 func GetMaxLimitFromEnv() int {
 	// Get the environment variable MAX_LIMIT, if not set default to 100
@@ -427,32 +556,42 @@ func (filter *FilterOptions) Validate() *FilterOptions {
 		offset := (filter.Page - 1) * filter.Limit
 		filter.Offset = &offset
 	}
-	// Clean up Type and Status
+	// Clean up Type, Status and Cursor
 	filter.Type = strings.TrimSpace(filter.Type)
 	filter.Status = strings.TrimSpace(filter.Status)
+	filter.Cursor = strings.TrimSpace(filter.Cursor)
 
 	// Clean up Dates
 	filter.StartDate = strings.TrimSpace(filter.StartDate)
 	filter.EndDate = strings.TrimSpace(filter.EndDate)
 
-	// Clean up Categories
-	if len(filter.Categories) > 0 {
-		cleanCategories := make([]string, 0)
-		for _, category := range filter.Categories {
-			if trimmed := strings.TrimSpace(category); trimmed != "" {
-				cleanCategories = append(cleanCategories, trimmed)
-			}
-		}
-		filter.Categories = cleanCategories
-	}
+	// Clean up Categories, Fields, Include and Facets
+	filter.Categories = cleanStringSlice(filter.Categories)
+	filter.Fields = cleanStringSlice(filter.Fields)
+	filter.Include = cleanStringSlice(filter.Include)
+	filter.Facets = cleanStringSlice(filter.Facets)
 
 	return filter
 }
 
+// cleanStringSlice trims every value and drops the empty ones
+func cleanStringSlice(values []string) []string {
+	if len(values) == 0 {
+		return values
+	}
+	cleaned := make([]string, 0)
+	for _, value := range values {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			cleaned = append(cleaned, trimmed)
+		}
+	}
+	return cleaned
+}
+
 func GeneratePaginatedResponse(data interface{}, totalData int, filter *FilterOptions) *PaginatedResponse {
 	totalPage := int(math.Ceil(float64(totalData) / float64(filter.Limit)))
 
-	return &PaginatedResponse{
+	resp := &PaginatedResponse{
 		TotalData:   totalData,
 		TotalPage:   totalPage,
 		CurrentPage: filter.Page,
@@ -460,6 +599,17 @@ func GeneratePaginatedResponse(data interface{}, totalData int, filter *FilterOp
 		Data:        data,
 		Filters:     filter,
 	}
+	// Data stays typed, it's projected to the requested sparse fieldsets when marshaled
+	if filter.HasFieldset() {
+		resp.fieldset = filter
+	}
+	return resp
+}
+
+// JSON sends the page with a 200 status, carrying the ID of the request
+func (r *PaginatedResponse) JSON(c echo.Context) error {
+	r.RequestID = requestID(c)
+	return c.JSON(http.StatusOK, r)
 }
 
 // Helper method to get UUID from dynamic fields
@@ -483,8 +633,8 @@ func (f *FilterOptions) GetDynamicUUIDs(key string) ([]uuid.UUID, bool) {
 }
 
 // For Echo framework
-func HandleFilterOptionsEcho(c echo.Context) (*FilterOptions, error) {
-	return ParseURLValues(c.QueryParams())
+func HandleFilterOptionsEcho(c echo.Context, opts ...ParseOption) (*FilterOptions, error) {
+	return ParseURLValues(c.QueryParams(), opts...)
 }
 
 // For Gin framework #TODO
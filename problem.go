@@ -0,0 +1,160 @@
+package goresponse
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MIMEApplicationProblemJSON is the media type of RFC 9457 problem details
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// ErrorFormat selects how a StandardErrorResponse is rendered
+type ErrorFormat int
+
+const (
+	// ErrorFormatStandard renders the {code,message,errors,request_id} shape
+	ErrorFormatStandard ErrorFormat = iota
+	// ErrorFormatProblem renders RFC 9457 application/problem+json
+	ErrorFormatProblem
+	// ErrorFormatNegotiate renders problem+json when the Accept header prefers it over JSON
+	ErrorFormatNegotiate
+)
+
+// ProblemDetails is an RFC 9457 problem details object. Extensions are rendered as
+// additional top-level members.
+type ProblemDetails struct {
+	Type       string                 `json:"type"`
+	Title      string                 `json:"title"`
+	Status     int                    `json:"status"`
+	Detail     string                 `json:"detail,omitempty"`
+	Instance   string                 `json:"instance,omitempty"`
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// problemTypeCatalog maps status codes to problem type URIs
+var problemTypeCatalog = struct {
+	sync.RWMutex
+	baseURL  string
+	byStatus map[int]string
+}{byStatus: make(map[int]string)}
+
+// SetProblemTypeBaseURL derives problem type URIs from baseURL, e.g.
+// https://errors.example.com/not-found for 404. Types registered for a status and
+// docs URLs of catalog errors take precedence.
+func SetProblemTypeBaseURL(baseURL string) {
+	problemTypeCatalog.Lock()
+	defer problemTypeCatalog.Unlock()
+	problemTypeCatalog.baseURL = strings.TrimSuffix(baseURL, "/")
+}
+
+// RegisterProblemType sets the problem type URI used for a status code
+func RegisterProblemType(status int, typeURI string) {
+	problemTypeCatalog.Lock()
+	defer problemTypeCatalog.Unlock()
+	problemTypeCatalog.byStatus[status] = typeURI
+}
+
+// problemType returns the type URI of a status, about:blank when none is configured
+func problemType(status int) string {
+	problemTypeCatalog.RLock()
+	defer problemTypeCatalog.RUnlock()
+
+	if typeURI, ok := problemTypeCatalog.byStatus[status]; ok {
+		return typeURI
+	}
+	if problemTypeCatalog.baseURL == "" || statusText(status) == "" {
+		return "about:blank"
+	}
+	slug := strings.ToLower(strings.NewReplacer(" ", "-", "'", "").Replace(statusText(status)))
+	return problemTypeCatalog.baseURL + "/" + slug
+}
+
+// problemType prefers the docs URL of a catalog error in the response over the status type
+func (ser *StandardErrorResponse) problemType() string {
+	for _, entry := range ser.Errors {
+		if def, ok := DefaultErrorCatalog.Lookup(entry["code"]); ok && def.DocsURL != "" {
+			return def.DocsURL
+		}
+	}
+	return problemType(ser.Code)
+}
+
+// MarshalJSON renders the standard members followed by the extension members
+func (p ProblemDetails) MarshalJSON() ([]byte, error) {
+	members := make(map[string]interface{}, len(p.Extensions)+5)
+	for key, value := range p.Extensions {
+		members[key] = value
+	}
+
+	// Standard members can't be overridden by extensions
+	members["type"] = p.Type
+	members["title"] = p.Title
+	members["status"] = p.Status
+	if p.Detail != "" {
+		members["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		members["instance"] = p.Instance
+	}
+	return json.Marshal(members)
+}
+
+// Problem converts the response into RFC 9457 problem details. Field errors are put in
+// the errors extension member.
+func (ser *StandardErrorResponse) Problem(instance string) *ProblemDetails {
+	problem := &ProblemDetails{
+		Type:       ser.problemType(),
+		Title:      statusText(ser.Code),
+		Status:     ser.Code,
+		Detail:     ser.Message,
+		Instance:   instance,
+		Extensions: make(map[string]interface{}),
+	}
+	if problem.Title == "" {
+		problem.Title = ser.Message
+	}
+	if len(ser.Errors) > 0 {
+		problem.Extensions["errors"] = ser.Errors
+	}
+	if ser.RequestID != "" {
+		problem.Extensions["request_id"] = ser.RequestID
+	}
+	if ser.ReferenceID != "" {
+		problem.Extensions["reference_id"] = ser.ReferenceID
+	}
+	if ser.Debug != nil {
+		problem.Extensions["debug"] = ser.Debug
+	}
+	if ser.RetryAfterSeconds > 0 {
+		problem.Extensions["retry_after_seconds"] = ser.RetryAfterSeconds
+	}
+	return problem
+}
+
+// ProblemJSON sends the response as application/problem+json, completed for the
+// request like JSON does
+func (ser *StandardErrorResponse) ProblemJSON(c echo.Context) error {
+	ser.prepare(c)
+	raw, err := json.Marshal(ser.Problem(c.Request().URL.Path))
+	if err != nil {
+		return err
+	}
+	return c.Blob(ser.Code, MIMEApplicationProblemJSON, raw)
+}
+
+// useProblem reports whether the format renders problem details for this request. With
+// ErrorFormatNegotiate problem+json has to rank above JSON in the Accept header.
+func (f ErrorFormat) useProblem(c echo.Context) bool {
+	switch f {
+	case ErrorFormatProblem:
+		return true
+	case ErrorFormatNegotiate:
+		accept := c.Request().Header.Get(echo.HeaderAccept)
+		return negotiateType(accept, []string{echo.MIMEApplicationJSON, MIMEApplicationProblemJSON}) == MIMEApplicationProblemJSON
+	default:
+		return false
+	}
+}
@@ -0,0 +1,116 @@
+package goresponse
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProblemJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		send func(ser *StandardErrorResponse, c echo.Context) error
+	}{
+		{
+			name: "JSON with the problem format",
+			send: func(ser *StandardErrorResponse, c echo.Context) error {
+				return ser.WithFormat(ErrorFormatProblem).JSON(c)
+			},
+		},
+		{
+			name: "ProblemJSON",
+			send: (*StandardErrorResponse).ProblemJSON,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/users", nil)
+			req.Header.Set("X-Request-ID", "test-request-id")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := tt.send(NewStandardErrorResponse(http.StatusUnprocessableEntity).AddMessageError("email", "Please provide email"), c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+			assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+			assert.JSONEq(t, `{
+				"type": "about:blank",
+				"title": "Unprocessable Entity",
+				"status": 422,
+				"detail": "The submitted data failed validation",
+				"instance": "/users",
+				"errors": [{"field": "email", "code": "UNPROCESSABLE_ENTITY", "message": "Please provide email"}],
+				"request_id": "test-request-id"
+			}`, rec.Body.String())
+		})
+	}
+}
+
+func TestProblemTypeCatalog(t *testing.T) {
+	t.Cleanup(func() {
+		SetProblemTypeBaseURL("")
+		problemTypeCatalog.Lock()
+		delete(problemTypeCatalog.byStatus, http.StatusConflict)
+		problemTypeCatalog.Unlock()
+	})
+
+	assert.Equal(t, "about:blank", NewStandardErrorResponse(http.StatusNotFound).Problem("").Type)
+
+	SetProblemTypeBaseURL("https://errors.example.com/")
+	RegisterProblemType(http.StatusConflict, "https://docs.example.com/conflict")
+
+	assert.Equal(t, "https://errors.example.com/not-found", NewStandardErrorResponse(http.StatusNotFound).Problem("").Type)
+	assert.Equal(t, "https://errors.example.com/im-a-teapot", NewStandardErrorResponse(http.StatusTeapot).Problem("").Type)
+	assert.Equal(t, "https://docs.example.com/conflict", NewStandardErrorResponse(http.StatusConflict).Problem("").Type)
+}
+
+func TestProblemDetailsExtensionsCannotOverride(t *testing.T) {
+	raw, err := json.Marshal(ProblemDetails{
+		Type:       "about:blank",
+		Title:      "Not Found",
+		Status:     http.StatusNotFound,
+		Extensions: map[string]interface{}{"status": 200, "resource": "user"},
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type":"about:blank","title":"Not Found","status":404,"resource":"user"}`, string(raw))
+}
+
+func TestErrorFormatNegotiate(t *testing.T) {
+	tests := []struct {
+		name        string
+		accept      string
+		contentType string
+	}{
+		{name: "problem json", accept: "application/problem+json", contentType: MIMEApplicationProblemJSON},
+		{name: "among others", accept: "text/html, application/problem+json;q=0.9", contentType: MIMEApplicationProblemJSON},
+		{name: "refused", accept: "application/problem+json;q=0, application/json", contentType: echo.MIMEApplicationJSON},
+		{name: "json ranks higher", accept: "application/json, application/problem+json;q=0.1", contentType: echo.MIMEApplicationJSON},
+		{name: "problem json ranks higher", accept: "application/json;q=0.5, application/problem+json", contentType: MIMEApplicationProblemJSON},
+		{name: "json on ties", accept: "application/json, application/problem+json", contentType: echo.MIMEApplicationJSON},
+		{name: "plain json", accept: "application/json", contentType: echo.MIMEApplicationJSON},
+		{name: "no accept", contentType: echo.MIMEApplicationJSON},
+	}
+
+	e := echo.New()
+	handler := NewErrorHandler(ErrorHandlerConfig{Format: ErrorFormatNegotiate})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echo.HeaderAccept, tt.accept)
+			rec := httptest.NewRecorder()
+
+			handler(echo.NewHTTPError(http.StatusNotFound, "user not found"), e.NewContext(req, rec))
+
+			assert.Equal(t, http.StatusNotFound, rec.Code)
+			assert.Contains(t, rec.Header().Get(echo.HeaderContentType), tt.contentType)
+			assert.Contains(t, rec.Body.String(), "user not found")
+		})
+	}
+}
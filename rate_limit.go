@@ -0,0 +1,98 @@
+package goresponse
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Headers of throttled and unavailable responses, the RateLimit ones after the IETF
+// RateLimit header fields draft
+const (
+	HeaderRetryAfter         = "Retry-After"
+	HeaderRateLimitLimit     = "RateLimit-Limit"
+	HeaderRateLimitRemaining = "RateLimit-Remaining"
+	HeaderRateLimitReset     = "RateLimit-Reset"
+)
+
+// RateLimit describes the quota of a client
+type RateLimit struct {
+	Limit     int           // Requests allowed in the window
+	Remaining int           // Requests left in the window
+	Reset     time.Duration // Time until the window resets
+}
+
+// TooManyRequests reports that the client exceeded its quota, e.g.
+// TooManyRequests(RateLimit{Limit: 100, Reset: 30 * time.Second}). The client may retry
+// when the quota resets.
+func TooManyRequests(limit RateLimit) *HTTPError {
+	err := errDefRateLimited.New()
+	err.RetryAfter = limit.Reset
+	err.RateLimit = &limit
+	return err
+}
+
+// Unavailable reports that the service is down, e.g. for maintenance, and may be retried
+// after retryAfter. A zero retryAfter leaves Retry-After out.
+func Unavailable(retryAfter time.Duration) *HTTPError {
+	err := errDefServiceUnavailable.New()
+	err.RetryAfter = retryAfter
+	return err
+}
+
+// WithRetryAfter tells the client when to retry, sent as Retry-After and
+// retry_after_seconds
+func (e *HTTPError) WithRetryAfter(retryAfter time.Duration) *HTTPError {
+	e.RetryAfter = retryAfter
+	return e
+}
+
+// WithRetryAfter tells the client when to retry, sent as the Retry-After header and
+// retry_after_seconds
+func (ser *StandardErrorResponse) WithRetryAfter(retryAfter time.Duration) *StandardErrorResponse {
+	ser.RetryAfterSeconds = retryAfterSeconds(retryAfter)
+	return ser
+}
+
+// WithRateLimit sends the quota of the client as RateLimit headers
+func (ser *StandardErrorResponse) WithRateLimit(limit RateLimit) *StandardErrorResponse {
+	ser.rateLimit = &limit
+	return ser
+}
+
+// addRetryInfo keeps the retry metadata of e, the latest retry of joined errors wins
+func (ser *StandardErrorResponse) addRetryInfo(e *HTTPError) {
+	if seconds := retryAfterSeconds(e.RetryAfter); seconds > ser.RetryAfterSeconds {
+		ser.RetryAfterSeconds = seconds
+	}
+	if e.RateLimit != nil {
+		ser.rateLimit = e.RateLimit
+	}
+}
+
+// setRetryHeaders sets the Retry-After and RateLimit headers of the response
+func (ser *StandardErrorResponse) setRetryHeaders(header http.Header) {
+	if ser.RetryAfterSeconds > 0 {
+		header.Set(HeaderRetryAfter, strconv.Itoa(ser.RetryAfterSeconds))
+	}
+	if ser.rateLimit != nil {
+		SetRateLimitHeaders(header, *ser.rateLimit)
+	}
+}
+
+// SetRateLimitHeaders sets the RateLimit headers of a response, also useful on
+// successful responses to let clients pace themselves
+func SetRateLimitHeaders(header http.Header, limit RateLimit) {
+	header.Set(HeaderRateLimitLimit, strconv.Itoa(limit.Limit))
+	header.Set(HeaderRateLimitRemaining, strconv.Itoa(max(limit.Remaining, 0)))
+	header.Set(HeaderRateLimitReset, strconv.Itoa(retryAfterSeconds(limit.Reset)))
+}
+
+// retryAfterSeconds rounds a delay up to whole seconds, so clients never retry early
+func retryAfterSeconds(retryAfter time.Duration) int {
+	if retryAfter <= 0 {
+		return 0
+	}
+	return int(math.Ceil(retryAfter.Seconds()))
+}
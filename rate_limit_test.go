@@ -0,0 +1,125 @@
+package goresponse
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryMetadata(t *testing.T) {
+	tests := []struct {
+		name        string
+		handler     echo.HandlerFunc
+		wantStatus  int
+		wantCode    string
+		wantSeconds int
+		wantHeaders map[string]string
+	}{
+		{
+			name: "too many requests",
+			handler: func(echo.Context) error {
+				return TooManyRequests(RateLimit{Limit: 100, Remaining: 0, Reset: 1500 * time.Millisecond})
+			},
+			wantStatus:  http.StatusTooManyRequests,
+			wantCode:    ErrCodeRateLimited,
+			wantSeconds: 2,
+			wantHeaders: map[string]string{
+				HeaderRetryAfter:         "2",
+				HeaderRateLimitLimit:     "100",
+				HeaderRateLimitRemaining: "0",
+				HeaderRateLimitReset:     "2",
+			},
+		},
+		{
+			name:        "unavailable",
+			handler:     func(echo.Context) error { return Unavailable(time.Minute) },
+			wantStatus:  http.StatusServiceUnavailable,
+			wantCode:    ErrCodeServiceUnavailable,
+			wantSeconds: 60,
+			wantHeaders: map[string]string{HeaderRetryAfter: "60", HeaderRateLimitLimit: ""},
+		},
+		{
+			name:        "unavailable without retry",
+			handler:     func(echo.Context) error { return Unavailable(0) },
+			wantStatus:  http.StatusServiceUnavailable,
+			wantCode:    ErrCodeServiceUnavailable,
+			wantHeaders: map[string]string{HeaderRetryAfter: ""},
+		},
+		{
+			name: "joined errors keep the latest retry",
+			handler: func(c echo.Context) error {
+				return NewFromError(errors.Join(Unavailable(30*time.Second), Conflict("").WithRetryAfter(time.Minute))).JSON(c)
+			},
+			wantStatus:  http.StatusServiceUnavailable,
+			wantCode:    ErrCodeServiceUnavailable,
+			wantSeconds: 60,
+			wantHeaders: map[string]string{HeaderRetryAfter: "60"},
+		},
+		{
+			name: "manual response",
+			handler: func(c echo.Context) error {
+				return NewStandardErrorResponse(http.StatusTooManyRequests).
+					AddCodedError("error", ErrCodeRateLimited, "Slow down").
+					WithRetryAfter(10 * time.Second).
+					WithRateLimit(RateLimit{Limit: 5, Remaining: -1, Reset: 10 * time.Second}).
+					JSON(c)
+			},
+			wantStatus:  http.StatusTooManyRequests,
+			wantCode:    ErrCodeRateLimited,
+			wantSeconds: 10,
+			wantHeaders: map[string]string{
+				HeaderRetryAfter:         "10",
+				HeaderRateLimitLimit:     "5",
+				HeaderRateLimitRemaining: "0",
+				HeaderRateLimitReset:     "10",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.HTTPErrorHandler = CustomErrorHandler
+			e.GET("/", tt.handler)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			var body StandardErrorResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantCode, body.Errors[0]["code"])
+			assert.Equal(t, tt.wantSeconds, body.RetryAfterSeconds)
+			for header, want := range tt.wantHeaders {
+				assert.Equal(t, want, rec.Header().Get(header), header)
+			}
+		})
+	}
+}
+
+func TestRetryMetadataProblem(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = NewErrorHandler(ErrorHandlerConfig{Format: ErrorFormatProblem})
+	e.GET("/", func(echo.Context) error { return Unavailable(5 * time.Second) })
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "5", rec.Header().Get(HeaderRetryAfter))
+	assert.Equal(t, float64(5), body["retry_after_seconds"])
+}
+
+func TestSetRateLimitHeaders(t *testing.T) {
+	header := http.Header{}
+	SetRateLimitHeaders(header, RateLimit{Limit: 100, Remaining: 42, Reset: 59*time.Second + time.Millisecond})
+
+	assert.Equal(t, "100", header.Get(HeaderRateLimitLimit))
+	assert.Equal(t, "42", header.Get(HeaderRateLimitRemaining))
+	assert.Equal(t, "60", header.Get(HeaderRateLimitReset))
+}
@@ -0,0 +1,85 @@
+package goresponse
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RecoverConfig configures the middlewares created by NewRecoverMiddleware and
+// NewRecoverHandler
+type RecoverConfig struct {
+	// StackTrace includes the panic's stack in the debug section in debug mode, also
+	// when SetDebugMode leaves stack traces out
+	StackTrace bool
+	// ErrorHandler configures how NewRecoverHandler responds to, logs and reports panics.
+	// NewRecoverMiddleware leaves that to the Echo error handler.
+	ErrorHandler ErrorHandlerConfig
+}
+
+// PanicError is a panic recovered by NewRecoverMiddleware or NewRecoverHandler
+type PanicError struct {
+	Value interface{} // The recovered value
+	Stack []byte      // Stack of the panicking goroutine
+
+	debugStack bool // Whether the debug section shows Stack, see RecoverConfig.StackTrace
+}
+
+// plainEcho renders responses for net/http handlers
+var plainEcho = echo.New()
+
+// NewRecoverMiddleware creates an Echo middleware that hands panics to the Echo error
+// handler as *PanicError, answered with a 500 carrying a reference ID and logged and
+// reported with the panic's stack. http.ErrAbortHandler is panicked again so the
+// server aborts the response.
+func NewRecoverMiddleware(config RecoverConfig) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					c.Error(newPanicError(config, recovered))
+				}
+			}()
+			return next(c)
+		}
+	}
+}
+
+// NewRecoverHandler is NewRecoverMiddleware for net/http handlers, panics are handled
+// like NewErrorHandler(config.ErrorHandler) does
+func NewRecoverHandler(config RecoverConfig) func(http.Handler) http.Handler {
+	handleError := NewErrorHandler(config.ErrorHandler)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					handleError(newPanicError(config, recovered), plainEcho.NewContext(r, w))
+				}
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// newPanicError keeps a recovered value with the stack of the panic, it must be called by
+// the deferred function so the stack shows where the panic happened
+func newPanicError(config RecoverConfig, recovered interface{}) *PanicError {
+	if err, ok := recovered.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+		panic(recovered)
+	}
+	return &PanicError{Value: recovered, Stack: debug.Stack(), debugStack: config.StackTrace}
+}
+
+// Error implements the error interface
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the recovered value when it's an error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
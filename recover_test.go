@@ -0,0 +1,173 @@
+package goresponse
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecoverMiddleware(t *testing.T) {
+	t.Cleanup(resetDebugMode)
+	var logs bytes.Buffer
+
+	tests := []struct {
+		name      string
+		recovered interface{}
+		config    RecoverConfig
+		debugMode bool
+		wantError string
+		wantStack bool
+	}{
+		{
+			name:      "panic value",
+			recovered: "index out of range",
+			wantError: "panic: index out of range",
+		},
+		{
+			name:      "panic error in debug mode",
+			recovered: errors.New("nil map"),
+			debugMode: true,
+			wantError: "panic: nil map",
+		},
+		{
+			name:      "stack trace in debug mode",
+			recovered: "boom",
+			config:    RecoverConfig{StackTrace: true},
+			debugMode: true,
+			wantError: "panic: boom",
+			wantStack: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetDebugMode(tt.debugMode, false)
+			logs.Reset()
+
+			e := echo.New()
+			e.HTTPErrorHandler = NewErrorHandler(ErrorHandlerConfig{Logger: slog.New(slog.NewJSONHandler(&logs, nil))})
+			e.Use(NewRecoverMiddleware(tt.config))
+			e.GET("/", func(echo.Context) error { panic(tt.recovered) })
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echo.HeaderXRequestID, "req-1")
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			var body StandardErrorResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			assert.Equal(t, ErrCodeInternal, body.Errors[0]["code"])
+			assert.Equal(t, "req-1", body.RequestID)
+			assert.NotEmpty(t, body.ReferenceID)
+
+			if tt.debugMode {
+				assert.Equal(t, tt.wantError, body.Debug.Error)
+				assert.Equal(t, tt.wantStack, len(body.Debug.Stack) > 0)
+			} else {
+				assert.Nil(t, body.Debug)
+			}
+
+			var entry map[string]interface{}
+			assert.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+			assert.Equal(t, body.ReferenceID, entry["reference_id"])
+			assert.Equal(t, "req-1", entry["request_id"])
+			assert.Equal(t, tt.wantError, entry["error"])
+			assert.Contains(t, entry["stack"], "recover_test.go")
+		})
+	}
+}
+
+func TestRecoverLogsWithoutLogger(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = NewErrorHandler(ErrorHandlerConfig{})
+	e.Use(NewRecoverMiddleware(RecoverConfig{}))
+	e.GET("/", func(echo.Context) error { panic("boom") })
+	handler := NewRecoverHandler(RecoverConfig{})(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+
+	for name, h := range map[string]http.Handler{"echo middleware": e, "net/http handler": handler} {
+		t.Run(name, func(t *testing.T) {
+			logs := captureDefaultLogs(t)
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(echo.HeaderXRequestID, "req-1")
+			h.ServeHTTP(httptest.NewRecorder(), req)
+
+			var entry map[string]interface{}
+			assert.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+			assert.Equal(t, "panic: boom", entry["error"])
+			assert.Equal(t, "req-1", entry["request_id"])
+			assert.Contains(t, entry["stack"], "recover_test.go")
+		})
+	}
+}
+
+func TestRecoverReportsOnce(t *testing.T) {
+	reporter := &MemoryReporter{}
+	config := ErrorHandlerConfig{Reporting: ReportingConfig{Reporter: reporter}}
+
+	e := echo.New()
+	e.HTTPErrorHandler = NewErrorHandler(config)
+	e.Use(NewRecoverMiddleware(RecoverConfig{}))
+	e.GET("/", func(echo.Context) error { panic("boom") })
+	handler := NewRecoverHandler(RecoverConfig{ErrorHandler: config})(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+
+	tests := []struct {
+		name    string
+		handler http.Handler
+	}{
+		{name: "echo middleware", handler: e},
+		{name: "net/http handler", handler: handler},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter.Reset()
+			rec := httptest.NewRecorder()
+			tt.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, http.StatusInternalServerError, rec.Code)
+			if assert.Len(t, reporter.Reports(), 1) {
+				var panicErr *PanicError
+				assert.ErrorAs(t, reporter.Reports()[0].Err, &panicErr)
+				assert.Equal(t, "boom", panicErr.Value)
+			}
+		})
+	}
+}
+
+func TestRecoverHandler(t *testing.T) {
+
+	handler := NewRecoverHandler(RecoverConfig{ErrorHandler: ErrorHandlerConfig{Format: ErrorFormatProblem}})(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var problem map[string]interface{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "application/problem+json", rec.Header().Get(echo.HeaderContentType))
+	assert.NotEmpty(t, problem["reference_id"])
+}
+
+func TestRecoverRepanicsAbortHandler(t *testing.T) {
+	handler := NewRecoverHandler(RecoverConfig{})(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
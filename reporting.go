@@ -0,0 +1,223 @@
+package goresponse
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxTrackedFingerprints bounds the rate limit windows kept in memory
+const maxTrackedFingerprints = 1024
+
+// digitsPattern matches the numbers that vary between occurrences of the same error, e.g.
+// IDs and ports
+var digitsPattern = regexp.MustCompile(`[0-9]+`)
+
+type (
+	// ErrorReport describes an error response forwarded to an ErrorReporter
+	ErrorReport struct {
+		Err         error
+		Status      int
+		Code        string
+		Category    ErrorCategory
+		Fingerprint string // Same for duplicates of an error, see ReportingConfig.RateLimit
+		RequestID   string
+		ReferenceID string
+		Method      string
+		Route       string
+		URI         string // Without the values of redacted query parameters
+		UserID      string
+		Tags        map[string]string
+		Time        time.Time
+	}
+	// ErrorReporter forwards error reports to an error tracker. Report is called by the
+	// error handler before responding, so it should hand reports off quickly.
+	ErrorReporter interface {
+		Report(ctx context.Context, report *ErrorReport)
+	}
+	// ErrorReporterFunc adapts a function to ErrorReporter
+	ErrorReporterFunc func(ctx context.Context, report *ErrorReport)
+
+	// ReportingConfig configures which error responses are reported, nothing is reported
+	// without a Reporter
+	ReportingConfig struct {
+		Reporter ErrorReporter
+		// StatusClasses reported, e.g. 5 for 5xx, only 5xx when empty
+		StatusClasses []int
+		// SampleRate is the share of errors reported between 0 and 1, all of them when 0
+		SampleRate float64
+		// RateLimit caps the reports per fingerprint and RateInterval, no cap when 0
+		RateLimit int
+		// RateInterval of RateLimit, a minute when 0
+		RateInterval time.Duration
+		// UserID returns the ID of the request's user
+		UserID func(c echo.Context) string
+		// Tags returns tags of the request, e.g. the tenant
+		Tags func(c echo.Context) map[string]string
+	}
+)
+
+// Report calls f
+func (f ErrorReporterFunc) Report(ctx context.Context, report *ErrorReport) {
+	f(ctx, report)
+}
+
+// errorReporting applies a ReportingConfig, keeping the rate limit windows across requests
+type errorReporting struct {
+	config  ReportingConfig
+	sample  func() float64
+	mu      sync.Mutex
+	windows map[string]*rateWindow
+}
+
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+func newErrorReporting(config ReportingConfig) *errorReporting {
+	if config.Reporter == nil {
+		return nil
+	}
+	if len(config.StatusClasses) == 0 {
+		config.StatusClasses = []int{5}
+	}
+	if config.RateInterval == 0 {
+		config.RateInterval = time.Minute
+	}
+	return &errorReporting{config: config, sample: rand.Float64, windows: make(map[string]*rateWindow)}
+}
+
+// report forwards the response the error handler sends for err, when it's configured
+// to and the sampling and rate limit let it through
+func (r *errorReporting) report(c echo.Context, err error, resp *StandardErrorResponse, redact []string) {
+	if r == nil || !r.reportsStatus(resp.Code) || !r.sampled() {
+		return
+	}
+
+	code := responseErrorCode(resp)
+	report := &ErrorReport{
+		Err:         err,
+		Status:      resp.Code,
+		Code:        code,
+		Category:    resp.Category(),
+		Fingerprint: ErrorFingerprint(c.Path(), code, err),
+		RequestID:   requestID(c),
+		ReferenceID: resp.ReferenceID,
+		Method:      c.Request().Method,
+		Route:       c.Path(),
+		URI:         redactURI(c.Request().URL, redact),
+		Time:        time.Now(),
+	}
+	if !r.allow(report.Fingerprint, report.Time) {
+		return
+	}
+	if r.config.UserID != nil {
+		report.UserID = r.config.UserID(c)
+	}
+	if r.config.Tags != nil {
+		report.Tags = r.config.Tags(c)
+	}
+	r.config.Reporter.Report(c.Request().Context(), report)
+}
+
+func (r *errorReporting) reportsStatus(status int) bool {
+	for _, class := range r.config.StatusClasses {
+		if status/100 == class {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *errorReporting) sampled() bool {
+	return r.config.SampleRate <= 0 || r.config.SampleRate >= 1 || r.sample() < r.config.SampleRate
+}
+
+// allow counts a report of fingerprint in its rate limit window
+func (r *errorReporting) allow(fingerprint string, now time.Time) bool {
+	if r.config.RateLimit <= 0 {
+		return true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	window, ok := r.windows[fingerprint]
+	if !ok || now.Sub(window.start) >= r.config.RateInterval {
+		if !ok && len(r.windows) >= maxTrackedFingerprints {
+			r.dropExpiredWindows(now)
+		}
+		if !ok && len(r.windows) >= maxTrackedFingerprints {
+			r.dropOldestWindow()
+		}
+		window = &rateWindow{start: now}
+		r.windows[fingerprint] = window
+	}
+	window.count++
+	return window.count <= r.config.RateLimit
+}
+
+func (r *errorReporting) dropExpiredWindows(now time.Time) {
+	for fingerprint, window := range r.windows {
+		if now.Sub(window.start) >= r.config.RateInterval {
+			delete(r.windows, fingerprint)
+		}
+	}
+}
+
+// dropOldestWindow makes room for a fingerprint when every tracked window is still running
+func (r *errorReporting) dropOldestWindow() {
+	var oldest string
+	for fingerprint, window := range r.windows {
+		if oldest == "" || window.start.Before(r.windows[oldest].start) {
+			oldest = fingerprint
+		}
+	}
+	delete(r.windows, oldest)
+}
+
+// ErrorFingerprint identifies duplicates of an error by its route, code and root cause,
+// ignoring the numbers in the cause's message
+func ErrorFingerprint(route, code string, err error) string {
+	chain := unwrapAll(err)
+	cause := chain[len(chain)-1]
+	message := digitsPattern.ReplaceAllString(cause.Error(), "0")
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\n%s\n%T\n%s", route, code, cause, message)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// MemoryReporter keeps reports in memory, a stand-in for an error tracker in tests and
+// local development. The zero value is ready to use.
+type MemoryReporter struct {
+	mu      sync.Mutex
+	reports []*ErrorReport
+}
+
+// Report keeps the report
+func (m *MemoryReporter) Report(_ context.Context, report *ErrorReport) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reports = append(m.reports, report)
+}
+
+// Reports returns the kept reports, oldest first
+func (m *MemoryReporter) Reports() []*ErrorReport {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*ErrorReport(nil), m.reports...)
+}
+
+// Reset drops the kept reports
+func (m *MemoryReporter) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reports = nil
+}
@@ -0,0 +1,149 @@
+package goresponse
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newReportingEcho(config ReportingConfig) *echo.Echo {
+	e := echo.New()
+	e.GET("/orders/:id", func(c echo.Context) error {
+		switch c.Param("id") {
+		case "missing":
+			return NotFound("order", c.Param("id"))
+		default:
+			return fmt.Errorf("load order %s: %w", c.Param("id"), errors.New("dial tcp 10.0.0.5:5432: connection refused"))
+		}
+	})
+	e.HTTPErrorHandler = NewErrorHandler(ErrorHandlerConfig{Reporting: config})
+	return e
+}
+
+func serveOrders(e *echo.Echo, ids ...string) {
+	for _, id := range ids {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orders/"+id+"?token=abc", nil))
+	}
+}
+
+func TestErrorReporting(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		config      ReportingConfig
+		ids         []string
+		wantReports int
+	}{
+		{
+			name:        "5xx only by default",
+			ids:         []string{"1", "missing"},
+			wantReports: 1,
+		},
+		{
+			name:        "4xx and 5xx",
+			config:      ReportingConfig{StatusClasses: []int{4, 5}},
+			ids:         []string{"1", "missing"},
+			wantReports: 2,
+		},
+		{
+			name:        "rate limited duplicates",
+			config:      ReportingConfig{RateLimit: 2},
+			ids:         []string{"1", "2", "3", "4"},
+			wantReports: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reporter := &MemoryReporter{}
+			tt.config.Reporter = reporter
+			serveOrders(newReportingEcho(tt.config), tt.ids...)
+			assert.Len(t, reporter.Reports(), tt.wantReports)
+		})
+	}
+}
+
+func TestErrorReportMetadata(t *testing.T) {
+
+	reporter := &MemoryReporter{}
+	e := newReportingEcho(ReportingConfig{
+		Reporter: reporter,
+		UserID:   func(echo.Context) string { return "user-7" },
+		Tags:     func(echo.Context) map[string]string { return map[string]string{"tenant": "acme"} },
+	})
+	e.Use(NewRequestIDMiddleware(RequestIDConfig{Generator: func() string { return "req-1" }}))
+	serveOrders(e, "1")
+
+	reports := reporter.Reports()
+	assert.Len(t, reports, 1)
+	report := reports[0]
+	assert.Equal(t, http.StatusInternalServerError, report.Status)
+	assert.Equal(t, ErrCodeInternal, report.Code)
+	assert.Equal(t, ErrorCategoryServer, report.Category)
+	assert.Equal(t, "req-1", report.RequestID)
+	assert.NotEmpty(t, report.ReferenceID)
+	assert.Equal(t, "/orders/:id", report.Route)
+	assert.Equal(t, "/orders/1?token=%5BREDACTED%5D", report.URI)
+	assert.Equal(t, "user-7", report.UserID)
+	assert.Equal(t, map[string]string{"tenant": "acme"}, report.Tags)
+	assert.EqualError(t, report.Err, "load order 1: dial tcp 10.0.0.5:5432: connection refused")
+
+	reporter.Reset()
+	assert.Empty(t, reporter.Reports())
+}
+
+func TestErrorReportingSampling(t *testing.T) {
+	reporting := newErrorReporting(ReportingConfig{Reporter: &MemoryReporter{}, SampleRate: 0.25})
+	samples := []float64{0.1, 0.3, 0.24, 0.9}
+	reporting.sample = func() float64 {
+		sample := samples[0]
+		samples = samples[1:]
+		return sample
+	}
+
+	var sampled []bool
+	for range 4 {
+		sampled = append(sampled, reporting.sampled())
+	}
+	assert.Equal(t, []bool{true, false, true, false}, sampled)
+}
+
+func TestErrorReportingRateWindow(t *testing.T) {
+	reporting := newErrorReporting(ReportingConfig{Reporter: &MemoryReporter{}, RateLimit: 1, RateInterval: time.Minute})
+	now := time.Now()
+
+	assert.True(t, reporting.allow("a", now))
+	assert.False(t, reporting.allow("a", now.Add(30*time.Second)))
+	assert.True(t, reporting.allow("b", now.Add(30*time.Second)))
+	assert.True(t, reporting.allow("a", now.Add(time.Minute)))
+}
+
+func TestErrorReportingTrackedFingerprints(t *testing.T) {
+	reporting := newErrorReporting(ReportingConfig{Reporter: &MemoryReporter{}, RateLimit: 1, RateInterval: time.Minute})
+	now := time.Now()
+
+	for i := 0; i < 5000; i++ {
+		assert.True(t, reporting.allow(strconv.Itoa(i), now.Add(time.Duration(i)*time.Millisecond)))
+	}
+	assert.Len(t, reporting.windows, maxTrackedFingerprints)
+	// The oldest windows were evicted, the latest are still limited
+	assert.NotContains(t, reporting.windows, "0")
+	assert.False(t, reporting.allow("4999", now.Add(5*time.Second)))
+}
+
+func TestErrorFingerprint(t *testing.T) {
+	first := ErrorFingerprint("/orders/:id", ErrCodeInternal, fmt.Errorf("load order 1: %w", errors.New("timeout after 30s")))
+	second := ErrorFingerprint("/orders/:id", ErrCodeInternal, fmt.Errorf("load order 2: %w", errors.New("timeout after 45s")))
+	otherRoute := ErrorFingerprint("/users/:id", ErrCodeInternal, errors.New("timeout after 30s"))
+
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, otherRoute)
+	assert.Len(t, first, 16)
+}
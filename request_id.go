@@ -0,0 +1,133 @@
+package goresponse
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// maxRequestIDLength caps incoming IDs, longer ones are replaced
+const maxRequestIDLength = 128
+
+// RequestIDConfig configures the middlewares created by NewRequestIDMiddleware and
+// NewRequestIDHandler
+type RequestIDConfig struct {
+	// Header carrying the ID in requests and responses, X-Request-ID by default
+	Header string
+	// Generator creates the ID of requests without one, uuid.NewString by default
+	Generator func() string
+	// Traceparent uses the trace ID of a W3C traceparent header for requests without an ID
+	Traceparent bool
+}
+
+type (
+	requestIDContextKey    struct{}
+	requestStartContextKey struct{}
+)
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID set with ContextWithRequestID
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// RequestStartFromContext returns when the request ID middleware received the request
+func RequestStartFromContext(ctx context.Context) (time.Time, bool) {
+	start, ok := ctx.Value(requestStartContextKey{}).(time.Time)
+	return start, ok
+}
+
+// NewRequestIDMiddleware creates an Echo middleware that keeps the request's ID, or
+// generates one, stores it in the request context and echoes it as a response header.
+// It also records when the request started, for the latency of error logs.
+func NewRequestIDMiddleware(config RequestIDConfig) echo.MiddlewareFunc {
+	config = config.withDefaults()
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.SetRequest(config.assign(c.Request(), c.Response().Header()))
+			return next(c)
+		}
+	}
+}
+
+// NewRequestIDHandler is NewRequestIDMiddleware for net/http handlers
+func NewRequestIDHandler(config RequestIDConfig) func(http.Handler) http.Handler {
+	config = config.withDefaults()
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, config.assign(r, w.Header()))
+		})
+	}
+}
+
+func (config RequestIDConfig) withDefaults() RequestIDConfig {
+	if config.Header == "" {
+		config.Header = echo.HeaderXRequestID
+	}
+	if config.Generator == nil {
+		config.Generator = uuid.NewString
+	}
+	return config
+}
+
+// assign sets the ID of r on the response header and returns r carrying it
+func (config RequestIDConfig) assign(r *http.Request, header http.Header) *http.Request {
+	id := r.Header.Get(config.Header)
+	if !validRequestID(id) {
+		id = ""
+	}
+	if id == "" && config.Traceparent {
+		id = traceparentTraceID(r.Header.Get("traceparent"))
+	}
+	if id == "" {
+		id = config.Generator()
+	}
+	header.Set(config.Header, id)
+	ctx := context.WithValue(r.Context(), requestStartContextKey{}, time.Now())
+	return r.WithContext(ContextWithRequestID(ctx, id))
+}
+
+// validRequestID accepts printable ASCII IDs, so they can't break logs or headers
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		if r <= ' ' || r > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// traceparentTraceID returns the trace ID of a W3C traceparent header, e.g.
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01, or "" when it's invalid
+func traceparentTraceID(traceparent string) string {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return ""
+	}
+	traceID := parts[1]
+	if len(traceID) != 32 || strings.Trim(traceID, "0123456789abcdef") != "" || strings.Trim(traceID, "0") == "" {
+		return ""
+	}
+	return traceID
+}
+
+// requestID returns the ID set by the request ID middleware, or the incoming
+// X-Request-ID header without it
+func requestID(c echo.Context) string {
+	if id := RequestIDFromContext(c.Request().Context()); id != "" {
+		return id
+	}
+	return c.Request().Header.Get(echo.HeaderXRequestID)
+}
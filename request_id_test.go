@@ -0,0 +1,132 @@
+package goresponse
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  RequestIDConfig
+		headers map[string]string
+		wantID  string
+	}{
+		{
+			name:    "incoming id",
+			headers: map[string]string{"X-Request-ID": "req-1"},
+			wantID:  "req-1",
+		},
+		{
+			name:    "custom header",
+			config:  RequestIDConfig{Header: "X-Correlation-ID"},
+			headers: map[string]string{"X-Correlation-ID": "corr-1", "X-Request-ID": "req-1"},
+			wantID:  "corr-1",
+		},
+		{
+			name:    "traceparent",
+			config:  RequestIDConfig{Traceparent: true},
+			headers: map[string]string{"traceparent": testTraceparent},
+			wantID:  "4bf92f3577b34da6a3ce929d0e0e4736",
+		},
+		{
+			name:    "invalid id is replaced",
+			config:  RequestIDConfig{Generator: func() string { return "generated" }},
+			headers: map[string]string{"X-Request-ID": "bad\nid"},
+			wantID:  "generated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Use(NewRequestIDMiddleware(tt.config))
+			e.GET("/", func(c echo.Context) error {
+				assert.Equal(t, tt.wantID, RequestIDFromContext(c.Request().Context()))
+				return NotFound("user", 7)
+			})
+			e.HTTPErrorHandler = CustomErrorHandler
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			var body StandardErrorResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantID, body.RequestID)
+			header := tt.config.Header
+			if header == "" {
+				header = echo.HeaderXRequestID
+			}
+			assert.Equal(t, tt.wantID, rec.Header().Get(header))
+		})
+	}
+}
+
+func TestRequestIDHandlerGeneratesUUID(t *testing.T) {
+	var id string
+	handler := NewRequestIDHandler(RequestIDConfig{})(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		id = RequestIDFromContext(r.Context())
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	_, err := uuid.Parse(id)
+	assert.NoError(t, err)
+	assert.Equal(t, id, rec.Header().Get(echo.HeaderXRequestID))
+}
+
+func TestSuccessResponsesCarryRequestID(t *testing.T) {
+	e := echo.New()
+	e.Use(NewRequestIDMiddleware(RequestIDConfig{Generator: func() string { return "req-9" }}))
+	e.GET("/user", func(c echo.Context) error {
+		return GenerateSingleDataResponse(map[string]string{"name": "Jane"}, "", http.StatusOK).JSON(c)
+	})
+	e.GET("/users", func(c echo.Context) error {
+		return GeneratePaginatedResponse([]string{"Jane"}, 1, &FilterOptions{Page: 1, Limit: 10}).JSON(c)
+	})
+
+	for _, path := range []string{"/user", "/users"} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+		var body map[string]interface{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "req-9", body["request_id"], path)
+	}
+}
+
+func TestTraceparentTraceID(t *testing.T) {
+	tests := []struct {
+		traceparent string
+		want        string
+	}{
+		{traceparent: testTraceparent, want: "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{traceparent: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", want: "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{traceparent: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", want: ""},
+		{traceparent: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", want: ""},
+		{traceparent: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", want: ""},
+		{traceparent: "00-4bf92f35-00f067aa0ba902b7-01", want: ""},
+		{traceparent: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.SplitN(tt.traceparent, "-", 2)[0], func(t *testing.T) {
+			assert.Equal(t, tt.want, traceparentTraceID(tt.traceparent))
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package goresponse
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type (
+	// SearchTerm is a single condition of a structured search query
+	SearchTerm struct {
+		Field   string // Qualifier name, empty for free text
+		Value   string
+		Phrase  bool // Value was a quoted phrase
+		Negated bool // Term was prefixed with -
+	}
+	// SearchClause is a group of terms that must all match
+	SearchClause struct {
+		Terms []SearchTerm
+	}
+	// SearchQuery is the parsed q parameter: clauses joined by OR
+	SearchQuery struct {
+		Clauses []SearchClause
+	}
+	// SearchSQLOptions controls how a SearchQuery is rendered as SQL
+	SearchSQLOptions struct {
+		// TextColumns are matched with LIKE for free text terms and phrases
+		TextColumns []string
+		// FieldColumns maps qualifier names to columns, unmapped qualifiers use the field name
+		FieldColumns map[string]string
+		// LikeOperator defaults to LIKE, use ILIKE for case-insensitive Postgres searches
+		LikeOperator string
+		// Placeholder renders the n-th (1-based) bind parameter, defaults to ?
+		Placeholder func(n int) string
+	}
+)
+
+// searchLikeEscape is used in LIKE ... ESCAPE clauses; unlike a backslash it needs no
+// escaping inside string literals on Postgres, MySQL or SQLite
+const searchLikeEscape = "!"
+
+var searchLikeReplacer = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// WithSearchQuery makes ParseURLValues parse the q parameter into FilterOptions.SearchQuery.
+// Only the given field names are read as field:value qualifiers, other words with a colon
+// are free text, e.g. 10:30.
+func WithSearchQuery(allowedFields ...string) ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.postParse = append(cfg.postParse, func(filter *FilterOptions, _ url.Values) error {
+			query, err := ParseSearchQuery(filter.Search, allowedFields...)
+			if err != nil {
+				return err
+			}
+			filter.SearchQuery = query
+			return nil
+		})
+	}
+}
+
+// DollarPlaceholder renders Postgres style bind parameters ($1, $2, ...)
+func DollarPlaceholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// ParseSearchQuery parses a search string that supports quoted phrases, -exclusions,
+// field:value qualifiers for allowedFields and OR between groups of terms. Words whose
+// prefix before a colon isn't an allowed field are free text.
+// Errors are returned as *QueryParamError for the q parameter.
+func ParseSearchQuery(q string, allowedFields ...string) (*SearchQuery, error) {
+	p := &searchParser{
+		input:   []rune(q),
+		allowed: make(map[string]struct{}, len(allowedFields)),
+	}
+	for _, field := range allowedFields {
+		p.allowed[strings.ToLower(field)] = struct{}{}
+	}
+	return p.parse()
+}
+
+type searchParser struct {
+	input   []rune
+	pos     int
+	allowed map[string]struct{}
+}
+
+func searchQueryError(format string, args ...interface{}) error {
+	return &QueryParamError{Param: "q", Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *searchParser) parse() (*SearchQuery, error) {
+	query := &SearchQuery{}
+	clause := SearchClause{}
+	for p.skipSpace() {
+		term, isOr, err := p.readTerm()
+		if err != nil {
+			return nil, err
+		}
+		if !isOr {
+			clause.Terms = append(clause.Terms, term)
+			continue
+		}
+		if len(clause.Terms) == 0 {
+			return nil, searchQueryError("OR must be placed between search terms")
+		}
+		query.Clauses = append(query.Clauses, clause)
+		clause = SearchClause{}
+	}
+
+	if len(clause.Terms) == 0 && len(query.Clauses) > 0 {
+		return nil, searchQueryError("OR must be placed between search terms")
+	}
+	if len(clause.Terms) > 0 {
+		query.Clauses = append(query.Clauses, clause)
+	}
+	return query, nil
+}
+
+// skipSpace advances past whitespace and reports whether input remains
+func (p *searchParser) skipSpace() bool {
+	for p.pos < len(p.input) && unicode.IsSpace(p.input[p.pos]) {
+		p.pos++
+	}
+	return p.pos < len(p.input)
+}
+
+func (p *searchParser) peek() rune {
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+// readTerm reads the next term, or reports an OR operator
+func (p *searchParser) readTerm() (SearchTerm, bool, error) {
+	term := SearchTerm{}
+	if p.peek() == '-' {
+		term.Negated = true
+		p.pos++
+	}
+
+	if p.peek() == '"' {
+		value, err := p.readQuoted()
+		term.Value, term.Phrase = value, true
+		return term, false, err
+	}
+
+	word := p.readWord()
+	switch {
+	case word == "OR" && !term.Negated:
+		return term, true, nil
+	case word == "":
+		return term, false, searchQueryError("Missing search term after \"-\"")
+	}
+
+	if field, value, ok := strings.Cut(word, ":"); ok && p.isAllowed(field) {
+		return p.readQualifier(term, field, value)
+	}
+	term.Value = word
+	return term, false, nil
+}
+
+// isAllowed reports whether field may be used as a qualifier
+func (p *searchParser) isAllowed(field string) bool {
+	_, ok := p.allowed[strings.ToLower(field)]
+	return ok
+}
+
+func (p *searchParser) readQualifier(term SearchTerm, field, value string) (SearchTerm, bool, error) {
+	term.Field = strings.ToLower(field)
+	if value == "" && p.peek() == '"' {
+		quoted, err := p.readQuoted()
+		term.Value, term.Phrase = quoted, true
+		return term, false, err
+	}
+	if value == "" {
+		return term, false, searchQueryError("Missing value for search field %q", field)
+	}
+	term.Value = value
+	return term, false, nil
+}
+
+// readWord reads until whitespace or the start of a quoted phrase
+func (p *searchParser) readWord() string {
+	start := p.pos
+	for p.pos < len(p.input) && !unicode.IsSpace(p.input[p.pos]) && p.input[p.pos] != '"' {
+		p.pos++
+	}
+	return string(p.input[start:p.pos])
+}
+
+func (p *searchParser) readQuoted() (string, error) {
+	p.pos++ // opening quote
+	start := p.pos
+	for p.pos < len(p.input) && p.input[p.pos] != '"' {
+		p.pos++
+	}
+	if p.pos >= len(p.input) {
+		return "", searchQueryError("Unclosed quote in search query")
+	}
+	value := string(p.input[start:p.pos])
+	p.pos++ // closing quote
+	return value, nil
+}
+
+// IsEmpty reports whether the query has no terms
+func (q *SearchQuery) IsEmpty() bool {
+	return q == nil || len(q.Clauses) == 0
+}
+
+// ToSQL renders the query as a parameterized SQL condition. LIKE wildcards in the
+// search values are escaped. An empty query renders as an empty string.
+func (q *SearchQuery) ToSQL(opts SearchSQLOptions) (string, []interface{}) {
+	if q.IsEmpty() {
+		return "", nil
+	}
+
+	b := &searchSQLBuilder{opts: opts}
+	clauses := make([]string, len(q.Clauses))
+	for i, clause := range q.Clauses {
+		terms := make([]string, len(clause.Terms))
+		for j, term := range clause.Terms {
+			terms[j] = b.term(term)
+		}
+		clauses[i] = "(" + strings.Join(terms, " AND ") + ")"
+	}
+	return strings.Join(clauses, " OR "), b.args
+}
+
+type searchSQLBuilder struct {
+	opts SearchSQLOptions
+	args []interface{}
+}
+
+func (b *searchSQLBuilder) bind(value interface{}) string {
+	b.args = append(b.args, value)
+	if b.opts.Placeholder == nil {
+		return "?"
+	}
+	return b.opts.Placeholder(len(b.args))
+}
+
+func (b *searchSQLBuilder) term(term SearchTerm) string {
+	var condition string
+	if term.Field != "" {
+		column := term.Field
+		if mapped, ok := b.opts.FieldColumns[term.Field]; ok {
+			column = mapped
+		}
+		condition = column + " = " + b.bind(term.Value)
+	} else {
+		condition = b.textCondition(term.Value)
+	}
+
+	if term.Negated {
+		return "NOT (" + condition + ")"
+	}
+	return condition
+}
+
+func (b *searchSQLBuilder) textCondition(value string) string {
+	if len(b.opts.TextColumns) == 0 {
+		return "1 = 0"
+	}
+	operator := b.opts.LikeOperator
+	if operator == "" {
+		operator = "LIKE"
+	}
+
+	pattern := "%" + searchLikeReplacer.Replace(value) + "%"
+	conditions := make([]string, len(b.opts.TextColumns))
+	for i, column := range b.opts.TextColumns {
+		conditions[i] = fmt.Sprintf("%s %s %s ESCAPE '%s'", column, operator, b.bind(pattern), searchLikeEscape)
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")"
+}
+
+// Matches evaluates the query in memory. Free text terms are searched in text and
+// qualifiers are compared with fields, both case-insensitively. An empty query matches.
+func (q *SearchQuery) Matches(text string, fields map[string]string) bool {
+	if q.IsEmpty() {
+		return true
+	}
+	text = strings.ToLower(text)
+	for _, clause := range q.Clauses {
+		if clause.matches(text, fields) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c SearchClause) matches(lowerText string, fields map[string]string) bool {
+	for _, term := range c.Terms {
+		var matched bool
+		if term.Field != "" {
+			matched = strings.EqualFold(fields[term.Field], term.Value)
+		} else {
+			matched = strings.Contains(lowerText, strings.ToLower(term.Value))
+		}
+		if matched == term.Negated {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,184 @@
+package goresponse
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		want        *SearchQuery
+		wantErr     bool
+		errContains string
+	}{
+		{
+			name:  "empty",
+			query: "   ",
+			want:  &SearchQuery{},
+		},
+		{
+			name:  "words, phrases and exclusions",
+			query: `golang "error handling" -java`,
+			want: &SearchQuery{Clauses: []SearchClause{{Terms: []SearchTerm{
+				{Value: "golang"},
+				{Value: "error handling", Phrase: true},
+				{Value: "java", Negated: true},
+			}}}},
+		},
+		{
+			name:  "qualifiers and OR",
+			query: `status:active author:"Jane Doe" OR -Status:archived`,
+			want: &SearchQuery{Clauses: []SearchClause{
+				{Terms: []SearchTerm{
+					{Field: "status", Value: "active"},
+					{Field: "author", Value: "Jane Doe", Phrase: true},
+				}},
+				{Terms: []SearchTerm{
+					{Field: "status", Value: "archived", Negated: true},
+				}},
+			}},
+		},
+		{
+			name:  "colons outside qualifiers are free text",
+			query: "meeting at 10:30 owner:me",
+			want: &SearchQuery{Clauses: []SearchClause{{Terms: []SearchTerm{
+				{Value: "meeting"},
+				{Value: "at"},
+				{Value: "10:30"},
+				{Value: "owner:me"},
+			}}}},
+		},
+		{
+			name:        "unclosed quote",
+			query:       `"error handling`,
+			wantErr:     true,
+			errContains: "Unclosed quote",
+		},
+		{
+			name:        "dangling OR",
+			query:       "golang OR",
+			wantErr:     true,
+			errContains: "OR must be placed between search terms",
+		},
+		{
+			name:        "missing qualifier value",
+			query:       "status:",
+			wantErr:     true,
+			errContains: `Missing value for search field "status"`,
+		},
+		{
+			name:        "lonely exclusion",
+			query:       "golang - java",
+			wantErr:     true,
+			errContains: "Missing search term",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSearchQuery(tt.query, "status", "author")
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errContains)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSearchQuery_ToSQL(t *testing.T) {
+	query, err := ParseSearchQuery(`"50%_off" -status:draft OR sale`, "status")
+	assert.NoError(t, err)
+
+	sql, args := query.ToSQL(SearchSQLOptions{
+		TextColumns:  []string{"title", "body"},
+		FieldColumns: map[string]string{"status": "posts.status"},
+		Placeholder:  DollarPlaceholder,
+	})
+
+	assert.Equal(t, "((title LIKE $1 ESCAPE '!' OR body LIKE $2 ESCAPE '!') AND NOT (posts.status = $3)) OR "+
+		"((title LIKE $4 ESCAPE '!' OR body LIKE $5 ESCAPE '!'))", sql)
+	assert.Equal(t, []interface{}{"%50!%!_off%", "%50!%!_off%", "draft", "%sale%", "%sale%"}, args)
+
+	sql, args = (&SearchQuery{}).ToSQL(SearchSQLOptions{})
+	assert.Empty(t, sql)
+	assert.Nil(t, args)
+}
+
+func TestSearchQuery_Matches(t *testing.T) {
+	query, err := ParseSearchQuery(`"error handling" -java OR status:featured`, "status")
+	assert.NoError(t, err)
+
+	assert.True(t, query.Matches("Error Handling in Go", nil))
+	assert.False(t, query.Matches("Error handling in Java", nil))
+	assert.True(t, query.Matches("Java streams", map[string]string{"status": "Featured"}))
+	assert.False(t, query.Matches("Go generics", map[string]string{"status": "draft"}))
+	assert.True(t, (&SearchQuery{}).Matches("anything", nil))
+}
+
+func TestParseURLValuesWithSearchQuery(t *testing.T) {
+	filter, err := ParseURLValues(url.Values{"q": []string{"  status:active golang "}}, WithSearchQuery("status"))
+	assert.NoError(t, err)
+	assert.Equal(t, "status:active golang", filter.Search)
+	assert.Len(t, filter.SearchQuery.Clauses, 1)
+
+	_, err = ParseURLValues(url.Values{"q": []string{"status:"}}, WithSearchQuery("status"))
+	assert.Error(t, err)
+
+	response := NewStandardErrorResponse(http.StatusUnprocessableEntity).AddError(err)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.Equal(t, "q", response.Errors[0]["field"])
+	assert.Equal(t, `Missing value for search field "status"`, response.Errors[0]["message"])
+}
+
+func TestErrorHandlerQueryParams(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantField string
+	}{
+		{name: "malformed search query", query: "q=" + url.QueryEscape(`"error handling`), wantField: "q"},
+		{name: "unknown relation", query: "include=author.company", wantField: "include"},
+		{name: "field outside the allowlist", query: "fields=id,password", wantField: "fields"},
+	}
+
+	e := echo.New()
+	e.HTTPErrorHandler = CustomErrorHandler
+	e.GET("/posts", func(c echo.Context) error {
+		_, err := HandleFilterOptionsEcho(c,
+			WithSearchQuery("status"),
+			WithIncludes(2, "author"),
+			WithAllowedFields("", "id", "title"))
+		if err != nil {
+			return err
+		}
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/posts?"+tt.query, nil))
+
+			var body StandardErrorResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Len(t, body.Errors, 1)
+			assert.Equal(t, tt.wantField, body.Errors[0]["field"])
+			assert.Equal(t, ErrCodeInvalidParameter, body.Errors[0]["code"])
+			assert.Empty(t, body.ReferenceID)
+		})
+	}
+}
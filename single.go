@@ -1,12 +1,20 @@
 package goresponse
 
-import "net/http"
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
 
 // SingleDataResponse defines the structure for API responses with a single data object
 type SingleDataResponse struct {
-	Code    int         `json:"code"`    // HTTP status code
-	Message string      `json:"message"` // Response message
-	Data    interface{} `json:"data"`    // Response data
+	Code      int                    `json:"code"`                 // HTTP status code
+	Message   string                 `json:"message"`              // Response message
+	Data      interface{}            `json:"data"`                 // Response data
+	Included  map[string]interface{} `json:"included,omitempty"`   // Side-loaded related resources
+	RequestID string                 `json:"request_id,omitempty"` // ID of the request, see NewRequestIDMiddleware
+
+	fieldset *FilterOptions // Sparse fieldsets applied to Data when it's marshaled
 }
 
 // GenerateSingleDataResponse creates a standard successful response
@@ -25,3 +33,25 @@ func GenerateSingleDataResponse(data interface{}, message string, statusCode int
 		Code:    statusCode,
 	}
 }
+
+// SelectFields projects Data down to the sparse fieldsets requested in filter when the
+// response is marshaled, Data itself is left untouched
+func (r *SingleDataResponse) SelectFields(filter *FilterOptions) *SingleDataResponse {
+	r.fieldset = filter
+	return r
+}
+
+// AddIncluded side-loads related resources under the given relation name
+func (r *SingleDataResponse) AddIncluded(name string, data interface{}) *SingleDataResponse {
+	if r.Included == nil {
+		r.Included = make(map[string]interface{})
+	}
+	r.Included[name] = data
+	return r
+}
+
+// JSON sends the response carrying the ID of the request
+func (r *SingleDataResponse) JSON(c echo.Context) error {
+	r.RequestID = requestID(c)
+	return c.JSON(r.Code, r)
+}
@@ -0,0 +1,100 @@
+package goresponse
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	// fieldParamTags take the name of another field as param
+	fieldParamTags = tagSet("eqfield", "eqcsfield", "nefield", "necsfield", "gtfield", "gtcsfield", "gtefield",
+		"gtecsfield", "ltfield", "ltcsfield", "ltefield", "ltecsfield", "fieldcontains", "fieldexcludes",
+		"postcode_iso3166_alpha2_field")
+	// fieldListTags take a list of field names as param
+	fieldListTags = tagSet("required_with", "required_with_all", "required_without", "required_without_all",
+		"excluded_with", "excluded_with_all", "excluded_without", "excluded_without_all")
+	// conditionTags take field and value pairs as param
+	conditionTags = tagSet("required_if", "required_unless", "excluded_if", "excluded_unless", "skip_unless")
+
+	oneOfValuePattern = regexp.MustCompile(`'[^']*'|\S+`)
+	timeType          = reflect.TypeOf(time.Time{})
+)
+
+func tagSet(tags ...string) map[string]struct{} {
+	set := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		set[tag] = struct{}{}
+	}
+	return set
+}
+
+// validationKind returns the kind of value a size tag is worded for: string, number,
+// items or time
+func validationKind(validationErr validator.FieldError) string {
+	if fieldType := validationErr.Type(); fieldType != nil && fieldType.ConvertibleTo(timeType) {
+		return "time"
+	}
+
+	switch validationErr.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	default:
+		return ""
+	}
+}
+
+// validationParam formats the param of a tag for messages, e.g. field names as labels
+// and oneof values as a list
+func validationParam(locale, tag, param string) string {
+	if _, ok := fieldParamTags[tag]; ok {
+		return paramFieldLabel(locale, param)
+	}
+	if _, ok := fieldListTags[tag]; ok {
+		fields := strings.Fields(param)
+		for i, field := range fields {
+			fields[i] = paramFieldLabel(locale, field)
+		}
+		return strings.Join(fields, ", ")
+	}
+	if _, ok := conditionTags[tag]; ok {
+		return formatConditions(locale, param)
+	}
+	if tag == "oneof" {
+		values := oneOfValuePattern.FindAllString(param, -1)
+		for i, value := range values {
+			values[i] = strings.Trim(value, "'")
+		}
+		return strings.Join(values, ", ")
+	}
+	return param
+}
+
+// formatConditions formats the field and value pairs of a conditional tag, e.g.
+// "Status active" as "status = active"
+func formatConditions(locale, param string) string {
+	parts := strings.Fields(param)
+	conditions := make([]string, 0, len(parts)/2)
+	for i := 0; i+1 < len(parts); i += 2 {
+		conditions = append(conditions, paramFieldLabel(locale, parts[i])+" = "+parts[i+1])
+	}
+	return strings.Join(conditions, ", ")
+}
+
+// paramFieldLabel labels a field named in a tag param, using the last segment of cross
+// struct names like Inner.Field
+func paramFieldLabel(locale, field string) string {
+	if i := strings.LastIndex(field, "."); i >= 0 {
+		field = field[i+1:]
+	}
+	return fieldLabel(locale, field)
+}
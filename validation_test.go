@@ -0,0 +1,131 @@
+package goresponse
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+// bakedInTags are the tags and aliases validator/v10 ships with
+var bakedInTags = strings.Fields(`required required_if required_unless skip_unless required_with
+	required_with_all required_without required_without_all excluded_if excluded_unless excluded_with
+	excluded_with_all excluded_without excluded_without_all isdefault len min max eq eq_ignore_case ne
+	ne_ignore_case lt lte gt gte eqfield eqcsfield necsfield gtcsfield gtecsfield ltcsfield ltecsfield
+	nefield gtefield gtfield ltefield ltfield fieldcontains fieldexcludes alpha alphanum alphaunicode
+	alphanumunicode boolean numeric number hexadecimal hexcolor rgb rgba hsl hsla e164 email url http_url
+	uri urn_rfc2141 file filepath base32 base64 base64url base64rawurl contains containsany containsrune
+	excludes excludesall excludesrune startswith endswith startsnotwith endsnotwith image isbn isbn10
+	isbn13 issn eth_addr eth_addr_checksum btc_addr btc_addr_bech32 uuid uuid3 uuid4 uuid5 uuid_rfc4122
+	uuid3_rfc4122 uuid4_rfc4122 uuid5_rfc4122 ulid md4 md5 sha256 sha384 sha512 ripemd128 ripemd160
+	tiger128 tiger160 tiger192 ascii printascii multibyte datauri latitude longitude ssn ipv4 ipv6 ip
+	cidrv4 cidrv6 cidr tcp4_addr tcp6_addr tcp_addr udp4_addr udp6_addr udp_addr ip4_addr ip6_addr
+	ip_addr unix_addr mac hostname hostname_rfc1123 fqdn unique oneof html html_encoded url_encoded dir
+	dirpath json jwt hostname_port lowercase uppercase datetime timezone iso3166_1_alpha2
+	iso3166_1_alpha2_eu iso3166_1_alpha3 iso3166_1_alpha3_eu iso3166_1_alpha_numeric
+	iso3166_1_alpha_numeric_eu iso3166_2 iso4217 iso4217_numeric bcp47_language_tag
+	postcode_iso3166_alpha2 postcode_iso3166_alpha2_field bic semver dns_rfc1035_label credit_card cve
+	luhn_checksum mongodb mongodb_connection_string cron spicedb iscolor country_code eu_country_code`)
+
+func TestValidationMessagesCoverBakedInTags(t *testing.T) {
+	for _, locale := range []string{"en", "id"} {
+		trans, ok := GetTranslator(locale)
+		assert.True(t, ok)
+		for _, tag := range bakedInTags {
+			_, err := trans.T(ValidationMessageKey(tag), "field", "param")
+			assert.NoError(t, err, "%s has no %s message", locale, tag)
+		}
+	}
+}
+
+type validatedOrder struct {
+	Name            string    `validate:"min=3"`
+	Quantity        int       `validate:"min=1"`
+	Tags            []string  `validate:"min=2"`
+	Color           string    `validate:"oneof=red green 'light blue'"`
+	Password        string    `validate:"required"`
+	ConfirmPassword string    `validate:"eqfield=Password"`
+	Phone           string    `validate:"e164"`
+	Status          string    `validate:"required"`
+	Reason          string    `validate:"required_if=Status rejected"`
+	DeliverAt       time.Time `validate:"gt"`
+}
+
+func TestValidationMessagesByKind(t *testing.T) {
+	err := validator.New().Struct(validatedOrder{
+		Name:            "ab",
+		Tags:            []string{"gift"},
+		Color:           "pink",
+		Password:        "secret",
+		ConfirmPassword: "secrets",
+		Phone:           "0812",
+		Status:          "rejected",
+		DeliverAt:       time.Now().Add(-time.Hour),
+	})
+
+	tests := []struct {
+		locale string
+		want   map[string]string
+	}{
+		{
+			locale: "en",
+			want: map[string]string{
+				"name":             "name must be at least 3 characters",
+				"quantity":         "quantity must be 1 or greater",
+				"tags":             "tags must contain at least 2 items",
+				"color":            "color must be one of red, green, light blue",
+				"confirm_password": "confirm password must match password",
+				"phone":            "Please enter a valid phone number in E.164 format for phone",
+				"reason":           "Please provide reason when status = rejected",
+				"deliver_at":       "deliver at must be in the future",
+			},
+		},
+		{
+			locale: "id",
+			want: map[string]string{
+				"name":             "name minimal 3 karakter",
+				"quantity":         "quantity harus 1 atau lebih",
+				"tags":             "tags harus berisi minimal 2 item",
+				"color":            "color harus salah satu dari red, green, light blue",
+				"confirm_password": "confirm password harus sama dengan password",
+				"phone":            "Mohon masukkan nomor telepon format E.164 yang valid untuk phone",
+				"reason":           "Mohon isi reason jika status = rejected",
+				"deliver_at":       "deliver at harus di masa depan",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			response := NewStandardErrorResponse(http.StatusUnprocessableEntity).AddError(err).WithLocale(tt.locale)
+			messages := make(map[string]string)
+			for _, entry := range response.Errors {
+				messages[entry["field"]] = entry["message"]
+			}
+			assert.Equal(t, tt.want, messages)
+		})
+	}
+}
+
+func TestRegisterValidationMessage(t *testing.T) {
+	v := validator.New()
+	assert.NoError(t, v.RegisterValidation("even", func(fl validator.FieldLevel) bool {
+		return fl.Field().Int()%2 == 0
+	}))
+	assert.NoError(t, RegisterValidationMessage("even", map[string]string{
+		"en": "{0} must be an even number",
+		"id": "{0} harus bilangan genap",
+	}))
+	assert.EqualError(t, RegisterValidationMessage("even", map[string]string{"xx": "{0}"}), "locale xx is not registered")
+
+	err := v.Struct(struct {
+		Seats int `validate:"even"`
+	}{Seats: 3})
+
+	response := NewStandardErrorResponse(http.StatusUnprocessableEntity).AddError(err)
+	assert.Equal(t, "seats must be an even number", response.Errors[0]["message"])
+	assert.Equal(t, "seats harus bilangan genap", response.WithLocale("id").Errors[0]["message"])
+}
@@ -0,0 +1,94 @@
+package goresponse
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+)
+
+// xmlNamePattern matches the keys that can be used as XML element names as they are
+var xmlNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// MarshalXML renders the response as a <response> element mirroring its JSON
+func (ser *StandardErrorResponse) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	return marshalXMLAsJSON(e, ser)
+}
+
+// MarshalXML renders the response as a <response> element mirroring its JSON
+func (r *SingleDataResponse) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	return marshalXMLAsJSON(e, r)
+}
+
+// MarshalXML renders the page as a <response> element mirroring its JSON
+func (r *PaginatedResponse) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	return marshalXMLAsJSON(e, r)
+}
+
+// marshalXMLAsJSON encodes the JSON of v as XML, so maps and interface{} data get the
+// same names and omissions as in JSON. Object members become elements named after their
+// keys, array items <item> elements and nulls are left out.
+func marshalXMLAsJSON(e *xml.Encoder, v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	return writeXMLValue(decoder, e, xml.StartElement{Name: xml.Name{Local: "response"}})
+}
+
+// writeXMLValue writes the next JSON value of decoder as the element start
+func writeXMLValue(decoder *json.Decoder, e *xml.Encoder, start xml.StartElement) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	switch token := token.(type) {
+	case json.Delim:
+		return writeXMLContainer(decoder, e, start, token)
+	case nil:
+		return nil
+	default:
+		return e.EncodeElement(fmt.Sprint(token), start)
+	}
+}
+
+// writeXMLContainer writes the members of a JSON object or the items of an array, its
+// opening delim already read, as children of start
+func writeXMLContainer(decoder *json.Decoder, e *xml.Encoder, start xml.StartElement, delim json.Delim) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for decoder.More() {
+		child := xml.StartElement{Name: xml.Name{Local: "item"}}
+		if delim == '{' {
+			key, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			child = xmlElement(key.(string))
+		}
+		if err := writeXMLValue(decoder, e, child); err != nil {
+			return err
+		}
+	}
+	// Closing delim
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+// xmlElement names an element after a JSON key, keys that aren't valid names become
+// <item key="..."> elements
+func xmlElement(key string) xml.StartElement {
+	if xmlNamePattern.MatchString(key) {
+		return xml.StartElement{Name: xml.Name{Local: key}}
+	}
+	return xml.StartElement{
+		Name: xml.Name{Local: "item"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: key}},
+	}
+}